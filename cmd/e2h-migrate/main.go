@@ -0,0 +1,58 @@
+/*
+Command e2h-migrate rewrites the usages of github.com/pkg/errors into the equivalent e2h functions:
+
+	errors.Wrap(err, message)        -> e2h.Tracem(err, message)
+	errors.Wrapf(err, format, args)  -> e2h.Tracef(err, format, args)
+	errors.WithStack(err)            -> e2h.Trace(err)
+	errors.Cause(err)                -> e2h.Cause(err)
+
+It rewrites the Go files of the provided paths (or the current directory), walking the directories recursively
+and skipping the generated files. Only the calls are rewritten, so the comments and formatting are preserved:
+
+	e2h-migrate --diff ./...
+
+Any other usage of the package (i.e. errors.New, errors.WithMessage or a function value) is reported as a warning
+and left unchanged, keeping its import. In that case, the command exits with a non-zero status.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cdleo/go-e2h/internal/codemod"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "e2h-migrate: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+// This function parses the arguments and rewrites the files of the provided paths
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+
+	flags := flag.NewFlagSet("e2h-migrate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	diff := flags.Bool("diff", false, "dry-run: print the unified diff of the changes instead of rewriting the files")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	warnings, err := codemod.Run(flags.Args(), migrate, codemod.Options{
+		Diff:   *diff,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+	if err != nil {
+		return err
+	}
+	if warnings > 0 {
+		return fmt.Errorf("%d usage(s) of %s could not be translated", warnings, pkgErrorsPath)
+	}
+
+	return nil
+}
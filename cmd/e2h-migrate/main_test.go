@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// This function copies the sample package into a temporary directory
+func copySample(t *testing.T) string {
+
+	dir, err := os.MkdirTemp("", "e2h-migrate")
+	require.Nil(t, err)
+	files, err := os.ReadDir(filepath.Join("testdata", "sample"))
+	require.Nil(t, err)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join("testdata", "sample", file.Name()))
+		require.Nil(t, err)
+		require.Nil(t, os.WriteFile(filepath.Join(dir, file.Name()), data, 0600))
+	}
+
+	return dir
+}
+
+// This function compares the output with the golden file, or updates it when the -update flag is set
+func checkGolden(t *testing.T, golden string, output []byte) {
+
+	if *update {
+		require.Nil(t, os.MkdirAll(filepath.Dir(golden), 0755))
+		require.Nil(t, os.WriteFile(golden, output, 0644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(output), golden)
+}
+
+func TestRun_Golden(t *testing.T) {
+
+	// Setup
+	dir := copySample(t)
+	defer os.RemoveAll(dir)
+
+	// Execute
+	var stdout, stderr bytes.Buffer
+	err := run([]string{dir}, &stdout, &stderr)
+
+	// Check
+	require.NotNil(t, err)
+	require.Equal(t, "3 usage(s) of github.com/pkg/errors could not be translated", err.Error())
+	require.Equal(t, "sample/untranslated.go:7:18: errors.New could not be translated to e2h\n"+
+		"sample/untranslated.go:11:10: errors.WithMessage could not be translated to e2h\n"+
+		"sample/untranslated.go:16:12: errors.Wrap could not be translated to e2h\n",
+		strings.ReplaceAll(stderr.String(), dir, "sample"))
+	files, err := os.ReadDir(dir)
+	require.Nil(t, err)
+	for _, file := range files {
+		output, err := os.ReadFile(filepath.Join(dir, file.Name()))
+		require.Nil(t, err)
+		checkGolden(t, filepath.Join("testdata", "golden", file.Name()+".golden"), output)
+	}
+}
+
+func TestRun_Diff(t *testing.T) {
+
+	// Setup
+	dir := copySample(t)
+	defer os.RemoveAll(dir)
+
+	// Execute
+	var stdout, stderr bytes.Buffer
+	require.NotNil(t, run([]string{"--diff", dir}, &stdout, &stderr))
+
+	// Check
+	checkGolden(t, filepath.Join("testdata", "golden", "diff.golden"), []byte(strings.ReplaceAll(stdout.String(), filepath.ToSlash(dir), "sample")))
+	original, err := os.ReadFile(filepath.Join("testdata", "sample", "repository.go"))
+	require.Nil(t, err)
+	current, err := os.ReadFile(filepath.Join(dir, "repository.go"))
+	require.Nil(t, err)
+	require.Equal(t, string(original), string(current))
+}
+
+func TestRun_Errors(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	require.NotNil(t, run([]string{"--unknown"}, &stdout, &stderr))
+	require.NotNil(t, run([]string{"missing"}, &stdout, &stderr))
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"go/ast"
+
+	"github.com/cdleo/go-e2h/internal/codemod"
+)
+
+const (
+	pkgErrorsPath = "github.com/pkg/errors"
+	pkgErrorsName = "errors"
+	e2hPath       = "github.com/cdleo/go-e2h"
+	e2hName       = "e2h"
+)
+
+// Equivalent e2h function of each pkg/errors one
+var translations = map[string]string{
+	"Wrap":      "Tracem",
+	"Wrapf":     "Tracef",
+	"WithStack": "Trace",
+	"Cause":     "Cause",
+}
+
+// This function records the edits that replace the pkg/errors calls with the e2h ones
+func migrate(file *codemod.File) {
+
+	name := file.ImportName(pkgErrorsPath, pkgErrorsName)
+	if len(name) == 0 || name == "_" {
+		return
+	}
+
+	// The selectors called as functions
+	called := make(map[*ast.SelectorExpr]bool)
+	ast.Inspect(file.AST, func(node ast.Node) bool {
+		if call, ok := node.(*ast.CallExpr); ok {
+			if selector, ok := call.Fun.(*ast.SelectorExpr); ok {
+				called[selector] = true
+			}
+		}
+		return true
+	})
+
+	untranslated := 0
+	ast.Inspect(file.AST, func(node ast.Node) bool {
+		selector, ok := node.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		pkg, ok := selector.X.(*ast.Ident)
+		if !ok || pkg.Name != name || pkg.Obj != nil {
+			return true
+		}
+
+		translation, ok := translations[selector.Sel.Name]
+		if !ok || !called[selector] {
+			file.Warnf(selector.Pos(), "%s.%s could not be translated to e2h", name, selector.Sel.Name)
+			untranslated++
+			return true
+		}
+		e2hPkg := file.AddImport(e2hPath, e2hName)
+		file.Replace(selector, e2hPkg+"."+translation)
+		return true
+	})
+
+	if untranslated == 0 && file.Changed() {
+		file.RemoveImport(pkgErrorsPath)
+	}
+}
@@ -0,0 +1,21 @@
+package sample
+
+import (
+	"errors"
+	"os"
+
+	pkgerrors "github.com/pkg/errors"
+)
+
+var errEmpty = errors.New("empty")
+
+func read(name string) ([]byte, error) {
+	data, err := os.ReadFile(name)
+	if err != nil {
+		return nil, pkgerrors.Wrap(err, "reading file")
+	}
+	if len(data) == 0 {
+		return nil, pkgerrors.WithStack(errEmpty)
+	}
+	return data, nil
+}
@@ -0,0 +1,41 @@
+// Package sample is a package using github.com/pkg/errors
+package sample
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+type Repository struct {
+	db *sql.DB
+}
+
+// Find looks for the user by its id
+func (r *Repository) Find(id int) (string, error) {
+	var name string
+	row := r.db.QueryRow("SELECT name FROM users WHERE id = ?", id)
+	if err := row.Scan(&name); err != nil {
+		// Keep the id on the message
+		return "", errors.Wrapf(err, "finding user %d", id)
+	}
+	return name, nil
+}
+
+func (r *Repository) Delete(id int) error {
+	_, err := r.db.Exec("DELETE FROM users WHERE id = ?", id)
+	return errors.Wrap(err, "deleting user") // Nil if there is no error
+}
+
+func (r *Repository) Close() error {
+	return errors.WithStack(r.db.Close())
+}
+
+func isNoRows(err error) bool {
+	return errors.Cause(err) == sql.ErrNoRows
+}
+
+func describe(err error) string {
+	return fmt.Sprintf("%v", err)
+}
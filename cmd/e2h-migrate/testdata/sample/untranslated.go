@@ -0,0 +1,16 @@
+package sample
+
+import (
+	"github.com/pkg/errors"
+)
+
+var errInvalid = errors.New("invalid")
+
+func validate(value string) error {
+	if value == "" {
+		return errors.WithMessage(errInvalid, "empty value")
+	}
+	return errors.Wrap(errInvalid, value)
+}
+
+var wrap = errors.Wrap
@@ -0,0 +1,51 @@
+/*
+Command e2h-rewrite traces the untraced error returns of existing code, rewriting them into e2h.Trace(err).
+
+It rewrites the Go files of the provided paths (or the current directory), walking the directories recursively
+and skipping the generated files. The e2h import is added when required:
+
+	e2h-rewrite --diff ./...
+	e2h-rewrite --tracem ./internal/...
+
+Only the errors held by local variables (or parameters) and, on functions with a single result, the errors returned
+directly from a call are traced. The package level errors (i.e. sentinels) are returned unchanged, and the errors
+already traced (by a tracer, a constructor or a definition, directly or by the last assignment of the variable) are kept.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cdleo/go-e2h/internal/codemod"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "e2h-rewrite: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+// This function parses the arguments and rewrites the files of the provided paths
+func run(args []string, stdout io.Writer, stderr io.Writer) error {
+
+	flags := flag.NewFlagSet("e2h-rewrite", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	diff := flags.Bool("diff", false, "dry-run: print the unified diff of the changes instead of rewriting the files")
+	tracem := flags.Bool("tracem", false, "use e2h.Tracem with the function name as message, instead of e2h.Trace")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	rewriter := &returnRewriter{tracem: *tracem}
+	_, err := codemod.Run(flags.Args(), rewriter.Rewrite, codemod.Options{
+		Diff:   *diff,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+
+	return err
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// This function copies the sample package into a temporary directory
+func copySample(t *testing.T) string {
+
+	dir, err := os.MkdirTemp("", "e2h-rewrite")
+	require.Nil(t, err)
+	files, err := os.ReadDir(filepath.Join("testdata", "sample"))
+	require.Nil(t, err)
+	for _, file := range files {
+		data, err := os.ReadFile(filepath.Join("testdata", "sample", file.Name()))
+		require.Nil(t, err)
+		require.Nil(t, os.WriteFile(filepath.Join(dir, file.Name()), data, 0600))
+	}
+
+	return dir
+}
+
+// This function compares the output with the golden file, or updates it when the -update flag is set
+func checkGolden(t *testing.T, golden string, output []byte) {
+
+	if *update {
+		require.Nil(t, os.MkdirAll(filepath.Dir(golden), 0755))
+		require.Nil(t, os.WriteFile(golden, output, 0644))
+	}
+	expected, err := os.ReadFile(golden)
+	require.Nil(t, err)
+	require.Equal(t, string(expected), string(output), golden)
+}
+
+func TestRun_Golden(t *testing.T) {
+
+	for _, mode := range []string{"trace", "tracem"} {
+		// Setup
+		dir := copySample(t)
+		defer os.RemoveAll(dir)
+		args := []string{dir + "/..."}
+		if mode == "tracem" {
+			args = append([]string{"--tracem"}, args...)
+		}
+
+		// Execute
+		var stdout, stderr bytes.Buffer
+		require.Nil(t, run(args, &stdout, &stderr))
+
+		// Check
+		require.Empty(t, stdout.String())
+		files, err := os.ReadDir(dir)
+		require.Nil(t, err)
+		for _, file := range files {
+			output, err := os.ReadFile(filepath.Join(dir, file.Name()))
+			require.Nil(t, err)
+			checkGolden(t, filepath.Join("testdata", "golden", mode, file.Name()+".golden"), output)
+		}
+	}
+}
+
+func TestRun_Diff(t *testing.T) {
+
+	// Setup
+	dir := copySample(t)
+	defer os.RemoveAll(dir)
+
+	// Execute
+	var stdout, stderr bytes.Buffer
+	require.Nil(t, run([]string{"--diff", dir}, &stdout, &stderr))
+
+	// Check
+	checkGolden(t, filepath.Join("testdata", "golden", "diff.golden"), []byte(strings.ReplaceAll(stdout.String(), filepath.ToSlash(dir), "sample")))
+	original, err := os.ReadFile(filepath.Join("testdata", "sample", "store.go"))
+	require.Nil(t, err)
+	current, err := os.ReadFile(filepath.Join(dir, "store.go"))
+	require.Nil(t, err)
+	require.Equal(t, string(original), string(current))
+}
+
+func TestRun_Errors(t *testing.T) {
+
+	var stdout, stderr bytes.Buffer
+	require.NotNil(t, run([]string{"--unknown"}, &stdout, &stderr))
+	require.NotNil(t, run([]string{"missing"}, &stdout, &stderr))
+}
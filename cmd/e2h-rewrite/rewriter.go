@@ -0,0 +1,359 @@
+package main
+
+import (
+	"go/ast"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/cdleo/go-e2h/internal/codemod"
+)
+
+const (
+	e2hPath = "github.com/cdleo/go-e2h"
+	e2hName = "e2h"
+)
+
+// Tracing functions of the e2h package
+var tracers = map[string]bool{
+	"Trace":  true,
+	"Tracem": true,
+	"Tracef": true,
+	// Variants with the request-scoped metadata of a context
+	"TraceCtx":  true,
+	"TracemCtx": true,
+	"TracefCtx": true,
+	// Constructors of errors already traced
+	"New":    true,
+	"Errorf": true,
+	// Returns the value of a (value, error) result, with the error traced
+	"Trace2": true,
+}
+
+// Entity that rewrites the untraced error returns of a file
+type returnRewriter struct {
+	tracem bool
+	// Names of the package level definitions (var ErrX = e2h.Define(...)) of each directory
+	definitions map[string]map[string]bool
+}
+
+// Entity with the function being rewritten
+type function struct {
+	file *codemod.File
+	// Outermost function declaration, that limits the local variables
+	decl ast.Node
+	name string
+	// Values assigned to each local variable, in source order
+	assignments map[*ast.Object][]assignment
+	// Names of the package level definitions of the file package
+	definitions map[string]bool
+}
+
+// Entity with a value assigned to a variable, and the end of its statement
+type assignment struct {
+	end   token.Pos
+	value ast.Expr
+}
+
+// This function records the edits that trace the error returns of the file
+func (r *returnRewriter) Rewrite(file *codemod.File) {
+
+	for _, decl := range file.AST.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		fn := &function{
+			file:        file,
+			decl:        funcDecl,
+			name:        funcName(funcDecl),
+			assignments: localAssignments(funcDecl),
+			definitions: r.packageDefinitions(file),
+		}
+		r.rewriteFunction(fn, funcDecl.Type, funcDecl.Body)
+	}
+}
+
+// This function rewrites the returns of the function body. The nested function literals are rewritten on its own
+func (r *returnRewriter) rewriteFunction(fn *function, funcType *ast.FuncType, body *ast.BlockStmt) {
+
+	indexes, count := errorResults(funcType)
+	if deferredWrap(fn.file, body) {
+		// The returned errors are traced on exit by: defer e2h.Wrap(&err, ...)
+		indexes = nil
+	}
+
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			r.rewriteFunction(fn, node.Type, node.Body)
+			return false
+		case *ast.ReturnStmt:
+			if len(node.Results) != count {
+				return true
+			}
+			for _, index := range indexes {
+				if expr := node.Results[index]; r.untraced(fn, expr, count) {
+					r.trace(fn, expr)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// This function returns true if the returned expression is an error that should be traced
+func (r *returnRewriter) untraced(fn *function, expr ast.Expr, results int) bool {
+
+	switch expr := expr.(type) {
+	case *ast.Ident:
+		object := expr.Obj
+		if object == nil || object.Kind != ast.Var || object.Pos() < fn.decl.Pos() || object.Pos() >= fn.decl.End() {
+			return false
+		}
+		// The variables assigned from a tracer (i.e. err = e2h.Tracem(err, ...)) are already traced
+		call, ok := fn.lastValue(object, expr.Pos()).(*ast.CallExpr)
+		return !ok || !fn.traced(call)
+	case *ast.CallExpr:
+		return results == 1 && !fn.traced(expr)
+	case *ast.ParenExpr:
+		return r.untraced(fn, expr.X, results)
+	}
+
+	return false
+}
+
+func (r *returnRewriter) trace(fn *function, expr ast.Expr) {
+
+	name := fn.file.AddImport(e2hPath, e2hName)
+	if r.tracem {
+		fn.file.Wrap(expr, name+".Tracem(", ", "+strconv.Quote(fn.name)+")")
+	} else {
+		fn.file.Wrap(expr, name+".Trace(", ")")
+	}
+}
+
+// This function returns true if the call returns a traced error: a tracer, a constructor or the New function of a definition
+func (fn *function) traced(call *ast.CallExpr) bool {
+
+	if isTracer(fn.file, call) {
+		return true
+	}
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "New" {
+		return false
+	}
+	switch x := selector.X.(type) {
+	case *ast.CallExpr:
+		// e2h.Define(...).New(...)
+		return isE2hCall(fn.file, x, "Define")
+	case *ast.Ident:
+		// ErrX.New(...), if ErrX is a package level definition (not shadowed by a local variable)
+		local := x.Obj != nil && x.Obj.Pos() >= fn.decl.Pos() && x.Obj.Pos() < fn.decl.End()
+		return !local && fn.definitions[x.Name]
+	}
+
+	return false
+}
+
+// This function returns the last value assigned to the variable before the position (or nil, if unknown)
+func (fn *function) lastValue(object *ast.Object, pos token.Pos) ast.Expr {
+
+	var value ast.Expr
+	for _, item := range fn.assignments[object] {
+		if item.end <= pos {
+			value = item.value
+		}
+	}
+	for {
+		paren, ok := value.(*ast.ParenExpr)
+		if !ok {
+			return value
+		}
+		value = paren.X
+	}
+}
+
+// This function returns the values assigned to the local variables of the function declaration
+func localAssignments(decl *ast.FuncDecl) map[*ast.Object][]assignment {
+
+	assignments := make(map[*ast.Object][]assignment)
+	add := func(lhs []ast.Expr, rhs []ast.Expr, end token.Pos) {
+		for i, expr := range lhs {
+			ident, ok := expr.(*ast.Ident)
+			if !ok || ident.Obj == nil {
+				continue
+			}
+			var value ast.Expr
+			switch {
+			case len(lhs) == len(rhs):
+				value = rhs[i]
+			case len(rhs) == 1:
+				value = rhs[0]
+			}
+			assignments[ident.Obj] = append(assignments[ident.Obj], assignment{end: end, value: value})
+		}
+	}
+
+	ast.Inspect(decl.Body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.AssignStmt:
+			add(node.Lhs, node.Rhs, node.End())
+		case *ast.ValueSpec:
+			names := make([]ast.Expr, 0, len(node.Names))
+			for _, name := range node.Names {
+				names = append(names, name)
+			}
+			add(names, node.Values, node.End())
+		}
+		return true
+	})
+
+	return assignments
+}
+
+// This function returns the names of the package level definitions (var ErrX = e2h.Define(...)) of the file
+// package, declared on any file of its directory
+func (r *returnRewriter) packageDefinitions(file *codemod.File) map[string]bool {
+
+	dir := filepath.Dir(file.Fset.Position(file.AST.Pos()).Filename)
+	if definitions, ok := r.definitions[dir]; ok {
+		return definitions
+	}
+
+	definitions := make(map[string]bool)
+	paths, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, path := range paths {
+		src, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		sibling, err := codemod.ParseFile(path, src)
+		if err != nil || sibling == nil || sibling.AST.Name.Name != file.AST.Name.Name {
+			continue
+		}
+		for _, name := range fileDefinitions(sibling) {
+			definitions[name] = true
+		}
+	}
+
+	if r.definitions == nil {
+		r.definitions = make(map[string]map[string]bool)
+	}
+	r.definitions[dir] = definitions
+
+	return definitions
+}
+
+// This function returns the names of the package level definitions declared on the file
+func fileDefinitions(file *codemod.File) []string {
+
+	var names []string
+	for _, decl := range file.AST.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			if len(valueSpec.Names) != len(valueSpec.Values) {
+				continue
+			}
+			for i, name := range valueSpec.Names {
+				if call, ok := valueSpec.Values[i].(*ast.CallExpr); ok && isE2hCall(file, call, "Define") {
+					names = append(names, name.Name)
+				}
+			}
+		}
+	}
+
+	return names
+}
+
+// This function returns the indexes of the error results, and the number of results
+func errorResults(funcType *ast.FuncType) ([]int, int) {
+
+	if funcType.Results == nil {
+		return nil, 0
+	}
+
+	var indexes []int
+	count := 0
+	for _, field := range funcType.Results.List {
+		names := len(field.Names)
+		if names == 0 {
+			names = 1
+		}
+		for i := 0; i < names; i++ {
+			if ident, ok := field.Type.(*ast.Ident); ok && ident.Name == "error" {
+				indexes = append(indexes, count)
+			}
+			count++
+		}
+	}
+
+	return indexes, count
+}
+
+// This function returns true if the expression is a call to an e2h tracing function
+func isTracer(file *codemod.File, call *ast.CallExpr) bool {
+
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+
+	return ok && tracers[selector.Sel.Name] && isE2hCall(file, call, selector.Sel.Name)
+}
+
+// This function returns true if the expression is a call to the named function of the e2h package
+func isE2hCall(file *codemod.File, call *ast.CallExpr, funcName string) bool {
+
+	name := file.ImportName(e2hPath, e2hName)
+	selector, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || len(name) == 0 || selector.Sel.Name != funcName {
+		return false
+	}
+	pkg, ok := selector.X.(*ast.Ident)
+
+	return ok && pkg.Name == name
+}
+
+// This function returns true if the function body defers a call to e2h.Wrap
+func deferredWrap(file *codemod.File, body *ast.BlockStmt) bool {
+
+	found := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			found = found || isE2hCall(file, node.Call, "Wrap")
+		}
+		return !found
+	})
+
+	return found
+}
+
+// This function returns the name of the function, including its receiver type (i.e. "Service.Load")
+func funcName(decl *ast.FuncDecl) string {
+
+	if decl.Recv == nil || len(decl.Recv.List) == 0 {
+		return decl.Name.Name
+	}
+
+	recv := decl.Recv.List[0].Type
+	for {
+		switch expr := recv.(type) {
+		case *ast.StarExpr:
+			recv = expr.X
+			continue
+		case *ast.IndexExpr:
+			recv = expr.X
+			continue
+		case *ast.Ident:
+			return expr.Name + "." + decl.Name.Name
+		}
+		return decl.Name.Name
+	}
+}
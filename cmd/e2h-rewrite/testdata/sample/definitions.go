@@ -0,0 +1,12 @@
+package sample
+
+import "github.com/cdleo/go-e2h"
+
+var errReadOnly = e2h.Define("READ_ONLY", "%s is read only")
+
+func checkWritable(name string, readOnly bool) error {
+	if readOnly {
+		return errReadOnly.New(name)
+	}
+	return nil
+}
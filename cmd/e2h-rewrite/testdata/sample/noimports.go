@@ -0,0 +1,8 @@
+package sample
+
+func first(errs []error) error {
+	for _, err := range errs {
+		return err
+	}
+	return nil
+}
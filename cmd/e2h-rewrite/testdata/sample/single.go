@@ -0,0 +1,13 @@
+package sample
+
+import "os"
+
+func create(name string) (file *os.File, err error) {
+	file, err = os.Create(name)
+	return
+}
+
+func stat(name string) error {
+	_, err := os.Stat(name)
+	return err
+}
@@ -0,0 +1,53 @@
+// Package sample is a package with untraced error returns
+package sample
+
+import (
+	"errors"
+	"fmt"
+	"os"
+)
+
+var ErrNotFound = errors.New("not found")
+
+type Store struct {
+	dir string
+}
+
+// Load reads the named entry
+func (s *Store) Load(name string) ([]byte, error) {
+	file, err := os.Open(s.dir + "/" + name)
+	if err != nil {
+		// The error is returned as is
+		return nil, err
+	}
+	defer file.Close()
+
+	data := make([]byte, 16)
+	if _, err := file.Read(data); err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, ErrNotFound
+	}
+
+	return data, nil
+}
+
+func (s Store) Remove(name string) error {
+	return os.Remove(s.dir + "/" + name)
+}
+
+func validate(name string) error {
+	if name == "" {
+		return fmt.Errorf("empty name")
+	}
+	check := func() error {
+		err := os.Remove(name)
+		return err
+	}
+	return check()
+}
+
+func count(names ...string) (int, error) {
+	return len(names), nil
+}
@@ -0,0 +1,5 @@
+package sample
+
+import "time"
+
+var zero time.Time
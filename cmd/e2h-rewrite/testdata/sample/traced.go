@@ -0,0 +1,71 @@
+package sample
+
+import (
+	"os"
+
+	tracer "github.com/cdleo/go-e2h"
+)
+
+func chmod(name string) error {
+	if err := os.Chmod(name, 0600); err != nil {
+		return tracer.Tracem(err, "changing mode")
+	}
+	return tracer.Trace(os.Chtimes(name, zero, zero))
+}
+
+func chown(name string) error {
+	err := os.Chown(name, 0, 0)
+	return err
+}
+
+func lchown(name string) (err error) {
+	defer tracer.Wrap(&err, "changing owner of %s", name)
+	err = os.Lchown(name, 0, 0)
+	return err
+}
+
+func validate(name string) error {
+	if name == "" {
+		return tracer.New("empty name")
+	}
+	return tracer.Errorf("invalid name %q", name)
+}
+
+var errNotRegular = tracer.Define("NOT_REGULAR", "%s is not a regular file")
+
+func remove(name string) error {
+	err := os.Remove(name)
+	if err != nil {
+		err = tracer.Tracem(err, "removing file")
+		return err
+	}
+	err = tracer.New("boom")
+	return err
+}
+
+func open(name string) (*os.File, error) {
+	file, err := tracer.Trace2(os.Open(name))
+	if err != nil {
+		return nil, err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if !info.Mode().IsRegular() {
+		err := errNotRegular.New(name)
+		return nil, err
+	}
+	return file, nil
+}
+
+func check(name string) error {
+	if name == "" {
+		return tracer.Define("EMPTY_NAME", "empty name").New()
+	}
+	return errNotRegular.New(name)
+}
+
+func protect(name string) error {
+	return errReadOnly.New(name) // Defined on definitions.go
+}
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+// Entity that re-renders the JSON error traces found on log lines
+type logRenderer struct {
+	formatter e2hformat.Formatter
+	params    e2hformat.Params
+}
+
+func newLogRenderer(format e2hformat.Format, params e2hformat.Params) (*logRenderer, error) {
+
+	formatter, err := e2hformat.NewFormatter(format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &logRenderer{
+		formatter: formatter,
+		params:    params,
+	}, nil
+}
+
+// This function reads the input line by line, writing into the output the lines with its traces re-rendered
+func (r *logRenderer) Render(in io.Reader, out io.Writer) error {
+
+	reader := bufio.NewReader(in)
+	for {
+		line, readErr := reader.ReadString('\n')
+		if len(line) > 0 {
+			line = strings.TrimRight(line, "\r\n")
+			if _, err := io.WriteString(out, r.renderLine(line)+"\n"); err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+		if readErr != nil {
+			return readErr
+		}
+	}
+}
+
+// This function returns the line with the JSON trace replaced by its rendered version. If the trace
+// is nested into another JSON object (i.e. a log entry), the rendered traces are added after the line
+func (r *logRenderer) renderLine(line string) string {
+
+	for start := strings.IndexByte(line, '{'); start >= 0; {
+		decoder := json.NewDecoder(strings.NewReader(line[start:]))
+		var value interface{}
+		if decoder.Decode(&value) != nil {
+			next := strings.IndexByte(line[start+1:], '{')
+			if next < 0 {
+				break
+			}
+			start += next + 1
+			continue
+		}
+		end := start + int(decoder.InputOffset())
+
+		if trace, ok := toTrace(value); ok {
+			return line[:start] + r.formatter.Format(trace, r.params) + line[end:]
+		}
+		if traces := findTraces(value); len(traces) > 0 {
+			rendered := make([]string, 0, len(traces)+1)
+			rendered = append(rendered, line)
+			for _, trace := range traces {
+				rendered = append(rendered, r.formatter.Format(trace, r.params))
+			}
+			return strings.Join(rendered, "\n")
+		}
+
+		next := strings.IndexByte(line[end:], '{')
+		if next < 0 {
+			break
+		}
+		start = end + next
+	}
+
+	return line
+}
+
+// This function looks for traces into the values of a JSON object or array, including
+// the string values with an embedded JSON trace (i.e. a pre-rendered field of a log entry)
+func findTraces(value interface{}) []e2h.EnhancedError {
+
+	var traces []e2h.EnhancedError
+	switch value := value.(type) {
+	case map[string]interface{}:
+		keys := make([]string, 0, len(value))
+		for key := range value {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			item := value[key]
+			if trace, ok := toTrace(item); ok {
+				traces = append(traces, trace)
+			} else {
+				traces = append(traces, findTraces(item)...)
+			}
+		}
+	case []interface{}:
+		for _, item := range value {
+			traces = append(traces, findTraces(item)...)
+		}
+	case string:
+		trimmed := strings.TrimSpace(value)
+		if strings.HasPrefix(trimmed, "{") {
+			var embedded interface{}
+			if json.Unmarshal([]byte(trimmed), &embedded) == nil {
+				if trace, ok := toTrace(embedded); ok {
+					traces = append(traces, trace)
+				} else {
+					traces = append(traces, findTraces(embedded)...)
+				}
+			}
+		}
+	}
+
+	return traces
+}
+
+// This function returns the enhanced error of the value, if it matches the JSON formatter
+// output shape (an object with the 'error' and 'stack_trace' fields)
+func toTrace(value interface{}) (e2h.EnhancedError, bool) {
+
+	object, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, false
+	}
+	_, causeOk := object["error"].(string)
+	_, stackOk := object["stack_trace"].([]interface{})
+	if !causeOk || !stackOk {
+		return nil, false
+	}
+
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, false
+	}
+	trace, err := e2hformat.ParseJSON(string(data), e2hformat.Params{})
+	if err != nil {
+		return nil, false
+	}
+
+	return trace, true
+}
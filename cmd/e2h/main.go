@@ -0,0 +1,113 @@
+/*
+Command e2h pretty-prints the JSON error traces (as rendered by the e2hformat JSON formatter) found on log lines.
+
+It reads NDJSON or plain text lines, with embedded JSON, from the files provided as arguments (or stdin),
+and re-renders every object with the 'error' and 'stack_trace' fields using the selected formatter:
+
+	kubectl logs my-pod | e2h --beautify --invert --hide-method folder --hide-value src
+
+The lines without error traces are printed unchanged. The module hiding method is not available, because
+the traces come from other binaries, whose modules can't be resolved from this one.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/cdleo/go-commons/formatter"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "e2h: %v\n", err)
+		os.Exit(2)
+	}
+}
+
+// This function parses the arguments and renders the input files (or stdin) into the output
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+
+	flags := flag.NewFlagSet("e2h", flag.ContinueOnError)
+	formatName := flags.String("format", "raw", "output format: raw or json")
+	beautify := flags.Bool("beautify", false, "beautify the output")
+	invert := flags.Bool("invert", false, "show the last trace at the top of the stack")
+	hideMethod := flags.String("hide-method", "none", "path hiding method: none, baseline or folder")
+	hideValue := flags.String("hide-value", "", "value to use, according to the selected hiding method")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := parseFormat(*formatName)
+	if err != nil {
+		return err
+	}
+	hidingMethod, err := parseHidingMethod(*hideMethod)
+	if err != nil {
+		return err
+	}
+	renderer, err := newLogRenderer(format, e2hformat.Params{
+		Beautify:         *beautify,
+		InvertCallstack:  *invert,
+		PathHidingMethod: hidingMethod,
+		PathHidingValue:  *hideValue,
+	})
+	if err != nil {
+		return err
+	}
+
+	if flags.NArg() == 0 {
+		return renderer.Render(stdin, stdout)
+	}
+
+	for _, name := range flags.Args() {
+		if name == "-" {
+			err = renderer.Render(stdin, stdout)
+		} else {
+			err = renderFile(renderer, name, stdout)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func renderFile(renderer *logRenderer, name string, stdout io.Writer) error {
+
+	file, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return renderer.Render(file, stdout)
+}
+
+func parseFormat(name string) (e2hformat.Format, error) {
+	switch name {
+	case "raw":
+		return e2hformat.Format_Raw, nil
+	case "json":
+		return e2hformat.Format_JSON, nil
+	default:
+		return 0, fmt.Errorf("unknown format [%s]", name)
+	}
+}
+
+func parseHidingMethod(name string) (formatter.HidingMethod, error) {
+	switch name {
+	case "none":
+		return formatter.HidingMethod_None, nil
+	case "baseline":
+		return formatter.HidingMethod_FullBaseline, nil
+	case "folder":
+		return formatter.HidingMethod_ToFolder, nil
+	default:
+		return 0, fmt.Errorf("unknown hiding method [%s]", name)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const jsonTrace = `{"error":"TheError","stack_trace":[{"func":"github.com/cdleo/go-e2h_test.foo","caller":"/src/go-e2h/e2h_example_test.go:24"},{"func":"github.com/cdleo/go-e2h_test.bar","caller":"/src/go-e2h/e2h_example_test.go:33","context":"Error executing foo()"}]}`
+
+func runWithInput(t *testing.T, args []string, input string) string {
+	var output bytes.Buffer
+	require.Nil(t, run(args, strings.NewReader(input), &output))
+	return output.String()
+}
+
+func TestRun_NDJSON(t *testing.T) {
+
+	// Execute
+	output := runWithInput(t, []string{"--beautify", "--invert", "--hide-method", "folder", "--hide-value", "go-e2h"}, jsonTrace+"\n")
+
+	// Check
+	require.Equal(t, "github.com/cdleo/go-e2h_test.bar (go-e2h/e2h_example_test.go:33)\n"+
+		"\tError executing foo()\n"+
+		"github.com/cdleo/go-e2h_test.foo (go-e2h/e2h_example_test.go:24)\n"+
+		"TheError\n", output)
+}
+
+func TestRun_PlainTextWithEmbeddedJSON(t *testing.T) {
+
+	// Execute
+	output := runWithInput(t, []string{"--hide-method", "folder", "--hide-value", "go-e2h"},
+		"2022-04-01T10:00:00Z ERROR request failed "+jsonTrace+" (took 3ms)\nplain line {not json}\n")
+
+	// Check
+	require.Equal(t, "2022-04-01T10:00:00Z ERROR request failed "+
+		"TheError; github.com/cdleo/go-e2h_test.foo (go-e2h/e2h_example_test.go:24); "+
+		"github.com/cdleo/go-e2h_test.bar (go-e2h/e2h_example_test.go:33) [Error executing foo()]; (took 3ms)\n"+
+		"plain line {not json}\n", output)
+}
+
+func TestRun_NestedTraces(t *testing.T) {
+
+	// Setup
+	escaped := strings.ReplaceAll(jsonTrace, `"`, `\"`)
+	input := `{"level":"error","msg":"failed","err":` + jsonTrace + `}` + "\n" +
+		`{"level":"error","msg":"failed","err":"` + escaped + `"}` + "\n"
+
+	// Execute
+	output := runWithInput(t, []string{"--format", "json", "--hide-method", "baseline", "--hide-value", "/src/go-e2h/"}, input)
+
+	// Check
+	rendered := `{"error":"TheError","stack_trace":[{"func":"github.com/cdleo/go-e2h_test.foo","caller":"e2h_example_test.go:24"},{"func":"github.com/cdleo/go-e2h_test.bar","caller":"e2h_example_test.go:33","context":"Error executing foo()"}]}`
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	require.Len(t, lines, 4)
+	require.True(t, strings.HasPrefix(lines[0], `{"level":"error"`))
+	require.Equal(t, rendered, lines[1])
+	require.True(t, strings.HasPrefix(lines[2], `{"level":"error"`))
+	require.Equal(t, rendered, lines[3])
+}
+
+func TestRun_Files(t *testing.T) {
+
+	// Setup
+	dir, err := os.MkdirTemp("", "e2h")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "app.log")
+	require.Nil(t, os.WriteFile(name, []byte("starting\n"+jsonTrace), 0600))
+
+	// Execute
+	output := runWithInput(t, []string{"--format", "raw", name, "-"}, "from stdin\n")
+
+	// Check
+	require.Equal(t, "starting\n"+
+		"TheError; github.com/cdleo/go-e2h_test.foo (/src/go-e2h/e2h_example_test.go:24); github.com/cdleo/go-e2h_test.bar (/src/go-e2h/e2h_example_test.go:33) [Error executing foo()];\n"+
+		"from stdin\n", output)
+}
+
+func TestRun_InvalidArgs(t *testing.T) {
+
+	var output bytes.Buffer
+	require.NotNil(t, run([]string{"--format", "xml"}, strings.NewReader(""), &output))
+	require.NotNil(t, run([]string{"--hide-method", "all"}, strings.NewReader(""), &output))
+	require.NotNil(t, run([]string{"--hide-method", "module"}, strings.NewReader(""), &output))
+	require.NotNil(t, run([]string{"missing.log"}, strings.NewReader(""), &output))
+}
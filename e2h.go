@@ -4,7 +4,9 @@ Package e2h its the package of the Enhanced Error Handling module
 package e2h
 
 import (
+	"errors"
 	"fmt"
+	"runtime"
 )
 
 // ExtendedError interface
@@ -22,17 +24,54 @@ type StackDetails struct {
 	Message  string
 }
 
+// Entity with the info captured on each trace. The program counter
+// is resolved into file, line and function name only when required
+type frame struct {
+	pc      uintptr
+	message string
+	format  string
+	//Details already resolved (i.e. rebuilt from a formatted output), used instead of the program counter
+	details *StackDetails
+}
+
 // Entity enhancedError with error and details
 type enhancedError struct {
-	err   error
-	stack []StackDetails
+	err    error
+	frames []frame
+	//Program counters of the full call stack at the first trace (only with Level_FullStack)
+	callStack []uintptr
+	//Program counters of the call stack that spawned the goroutine where the error was returned (see Group)
+	spawnedFrom []uintptr
+	//Request-scoped metadata attached by the TraceCtx functions
+	fields map[string]string
+	//Context error (i.e. context.Canceled) replaced by the cause of the cancellation on TraceCtx, still matched by errors.Is
+	contextErr error
+}
+
+// This function returns an enhanced error with the provided cause and callstack details
+// (i.e. parsed from the output of a formatter), that could be traced like any other one
+func Rebuild(cause error, stack []StackDetails) EnhancedError {
+
+	frames := make([]frame, 0, len(stack)+4)
+	for i := range stack {
+		details := stack[i]
+		frames = append(frames, frame{
+			message: details.Message,
+			details: &details,
+		})
+	}
+
+	return &enhancedError{
+		err:    cause,
+		frames: frames,
+	}
 }
 
 // This function returns the Error string plus the origin custom message (if exists)
 func (e *enhancedError) Error() string {
 
-	if len(e.stack[0].Message) > 0 {
-		return fmt.Sprintf("%s: %s", e.err.Error(), e.stack[0].Message)
+	if len(e.frames) > 0 && len(e.frames[0].message) > 0 {
+		return fmt.Sprintf("%s: %s", e.err.Error(), e.frames[0].message)
 	}
 
 	return e.err.Error()
@@ -43,7 +82,107 @@ func (e *enhancedError) Cause() error {
 	return e.err
 }
 
+// This function returns the source error, in order to reach the wrapped errors with errors.Is and errors.As
+func (e *enhancedError) Unwrap() error {
+	return e.err
+}
+
+// This function returns true if the target is the context error replaced by the cause of the cancellation
+// (see TraceCtx), so errors.Is(err, context.Canceled) holds. The cause chain is matched through Unwrap
+func (e *enhancedError) Is(target error) bool {
+	return e.contextErr != nil && errors.Is(e.contextErr, target)
+}
+
 // This function returns the callstack details
 func (e *enhancedError) Stack() []StackDetails {
-	return e.stack
+
+	stack := make([]StackDetails, 0, len(e.frames))
+	for _, item := range e.frames {
+		stack = append(stack, item.resolve())
+	}
+
+	return stack
+}
+
+// This function returns the full call stack captured at the first trace (if exists)
+func (e *enhancedError) CallStack() []StackDetails {
+	return resolveStack(e.callStack)
+}
+
+// This function returns the call stack of the spawning call site of the goroutine that returned the error (if exists)
+func (e *enhancedError) SpawnedFrom() []StackDetails {
+	return resolveStack(e.spawnedFrom)
+}
+
+// This function returns the request-scoped metadata attached by the TraceCtx functions (if exists)
+func (e *enhancedError) Fields() map[string]string {
+
+	if len(e.fields) == 0 {
+		return nil
+	}
+
+	fields := make(map[string]string, len(e.fields))
+	for field, value := range e.fields {
+		fields[field] = value
+	}
+
+	return fields
+}
+
+// This function resolves the program counters into the stack details, or returns nil if there are none
+func resolveStack(pcs []uintptr) []StackDetails {
+
+	if len(pcs) == 0 {
+		return nil
+	}
+
+	stack := make([]StackDetails, 0, len(pcs))
+	callerFrames := runtime.CallersFrames(pcs)
+	for {
+		callerFrame, more := callerFrames.Next()
+		stack = append(stack, StackDetails{
+			File:     callerFrame.File,
+			Line:     callerFrame.Line,
+			FuncName: callerFrame.Function,
+		})
+		if !more {
+			break
+		}
+	}
+
+	return stack
+}
+
+// This function returns the frame details, resolving its program counter
+func (f *frame) resolve() StackDetails {
+
+	if f.details != nil {
+		return *f.details
+	}
+
+	details := StackDetails{
+		Message: f.message,
+	}
+
+	if f.pc != 0 {
+		callerFrame, _ := runtime.CallersFrames([]uintptr{f.pc}).Next()
+		details.File = callerFrame.File
+		details.Line = callerFrame.Line
+		details.FuncName = callerFrame.Function
+	}
+
+	return details
+}
+
+// This function returns the underlying cause of the error, if it's an EnhancedError (even if it's wrapped
+// by several of them), or the error itself otherwise
+func Cause(err error) error {
+
+	for {
+		enhancedErr, ok := err.(EnhancedError)
+		if !ok {
+			return err
+		}
+		err = enhancedErr.Cause()
+	}
 }
@@ -0,0 +1,72 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"runtime"
+	"strings"
+)
+
+// This function returns the program counter of the caller of the trace function
+// (skipping runtime.Callers, callerPC, addTrace and the trace function itself)
+func callerPC() uintptr {
+	var pcs [1]uintptr
+	runtime.Callers(4, pcs[:])
+	return pcs[0]
+}
+
+// This function returns the program counter of the function that deferred the call to the trace function
+// (skipping runtime.Callers, deferCallerPC, addTrace, the trace function itself and the defer machinery of the runtime)
+func deferCallerPC() uintptr {
+
+	var pcs [8]uintptr
+	for _, pc := range pcs[:runtime.Callers(4, pcs[:])] {
+		if fn := runtime.FuncForPC(pc - 1); fn == nil || !strings.HasPrefix(fn.Name(), "runtime.") {
+			return pc
+		}
+	}
+
+	return 0
+}
+
+// This function returns the program counters of the call stack of the caller of the trace function
+// (or the caller of the Group functions that spawns a goroutine, as both are two frames above)
+func callStackPCs() []uintptr {
+	pcs := make([]uintptr, maxCallStackDepth)
+	return pcs[:runtime.Callers(4, pcs)]
+}
+
+// This function returns the program counters of the call stack of the panicking function, starting at the
+// frame where the panic happened (skipping runtime.Callers, panicStackPCs, the trace function, the deferred
+// function that recovered it and the panic machinery of the runtime). It returns nil if there is no panic
+func panicStackPCs() []uintptr {
+
+	pcs := make([]uintptr, maxCallStackDepth)
+	pcs = pcs[:runtime.Callers(3, pcs)]
+	for i, pc := range pcs {
+		if funcName(pc) != "runtime.gopanic" {
+			continue
+		}
+		start := i + 1
+		for start < len(pcs) && strings.HasPrefix(funcName(pcs[start]), "runtime.") {
+			start++
+		}
+		return pcs[start:]
+	}
+
+	return nil
+}
+
+// This function returns the name of the function of a return program counter, or an empty string if it's unknown
+func funcName(pc uintptr) string {
+
+	if fn := runtime.FuncForPC(pc - 1); fn != nil {
+		return fn.Name()
+	}
+
+	return ""
+}
@@ -0,0 +1,27 @@
+//go:build e2h_nocaller
+// +build e2h_nocaller
+
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+// With the 'e2h_nocaller' build tag, the caller lookup is compiled out and no frames are captured
+func callerPC() uintptr {
+	return 0
+}
+
+// With the 'e2h_nocaller' build tag, the caller lookup is compiled out and no frames are captured
+func deferCallerPC() uintptr {
+	return 0
+}
+
+// With the 'e2h_nocaller' build tag, the caller lookup is compiled out and no frames are captured
+func callStackPCs() []uintptr {
+	return nil
+}
+
+// With the 'e2h_nocaller' build tag, the caller lookup is compiled out and no frames are captured
+func panicStackPCs() []uintptr {
+	return nil
+}
@@ -0,0 +1,128 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"container/list"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Default amount of different errors kept by a Collector
+const DefaultCollectorCapacity = 1000
+
+// Entity with the statistics of the errors that shares the same fingerprint
+type ErrorStats struct {
+	Fingerprint string
+	Count       uint64
+	FirstSeen   time.Time
+	LastSeen    time.Time
+	//Representative error of the group (a copy of the first one collected, so the later traces don't change it)
+	Sample error
+	//Callstack details of the representative error, as it was when collected
+	Stack []StackDetails
+}
+
+// Entity that groups the collected errors by fingerprint, keeping at most 'capacity' groups.
+// When full, the least recently seen group is evicted
+type Collector struct {
+	mutex    sync.Mutex
+	capacity int
+	entries  map[string]*list.Element
+	lru      *list.List
+}
+
+// This function returns a new Collector, that keeps up to 'capacity' different errors
+// (DefaultCollectorCapacity if the value is not positive)
+func NewCollector(capacity int) *Collector {
+
+	if capacity <= 0 {
+		capacity = DefaultCollectorCapacity
+	}
+
+	return &Collector{
+		capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+}
+
+// This function adds the error to the statistics. Nil errors are ignored
+func (c *Collector) Collect(err error) {
+
+	if err == nil {
+		return
+	}
+
+	fingerprint := Fingerprint(err)
+	now := time.Now()
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if element, found := c.entries[fingerprint]; found {
+		stats := element.Value.(*ErrorStats)
+		stats.Count++
+		stats.LastSeen = now
+		c.lru.MoveToFront(element)
+		return
+	}
+
+	stats := &ErrorStats{
+		Fingerprint: fingerprint,
+		Count:       1,
+		FirstSeen:   now,
+		LastSeen:    now,
+		Sample:      cloneError(err),
+	}
+	if enhancedErr, ok := err.(EnhancedError); ok {
+		stats.Stack = append([]StackDetails(nil), enhancedErr.Stack()...)
+	}
+	c.entries[fingerprint] = c.lru.PushFront(stats)
+
+	if c.lru.Len() > c.capacity {
+		oldest := c.lru.Back()
+		c.lru.Remove(oldest)
+		delete(c.entries, oldest.Value.(*ErrorStats).Fingerprint)
+	}
+}
+
+// This function returns a copy of the statistics, sorted by count (most frequent first)
+func (c *Collector) Snapshot() []ErrorStats {
+
+	c.mutex.Lock()
+	result := make([]ErrorStats, 0, c.lru.Len())
+	for element := c.lru.Front(); element != nil; element = element.Next() {
+		result = append(result, *element.Value.(*ErrorStats))
+	}
+	c.mutex.Unlock()
+
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Count > result[j].Count
+	})
+
+	return result
+}
+
+// This function returns the 'n' most frequent errors
+func (c *Collector) Top(n int) []ErrorStats {
+
+	result := c.Snapshot()
+	if n >= 0 && n < len(result) {
+		result = result[:n]
+	}
+
+	return result
+}
+
+// This function removes all the collected statistics
+func (c *Collector) Reset() {
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	c.entries = make(map[string]*list.Element)
+	c.lru.Init()
+}
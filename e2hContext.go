@@ -0,0 +1,180 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+)
+
+// Well-known fields of the request-scoped metadata
+const (
+	Field_RequestID   = "request_id"
+	Field_Tenant      = "tenant"
+	Field_User        = "user"
+	Field_TraceParent = "traceparent"
+)
+
+// Interface implemented by the enhanced errors, to get the request-scoped metadata attached by the TraceCtx functions
+type Fielder interface {
+	// This function returns the fields attached to the error, or nil if there are none
+	Fields() map[string]string
+}
+
+// Function that returns the value of a field from the context, and if it was found
+type Extractor func(ctx context.Context) (string, bool)
+
+type registeredExtractor struct {
+	id        uint64
+	field     string
+	extractor Extractor
+}
+
+type contextKey struct{}
+
+var (
+	extractorsMutex sync.Mutex
+	extractorsID    uint64
+	// Current []registeredExtractor. Replaced (never modified) on every change, to be read without locking
+	extractors atomic.Value
+)
+
+// This function calls the addTrace with the metadata of the context, in order to create or add stack info
+func TraceCtx(ctx context.Context, e error) error {
+	return addTrace(ctx, callerPC, e, "")
+}
+
+// Same as TraceCtx, but adding a descriptive message
+func TracemCtx(ctx context.Context, e error, message string) error {
+	return addTrace(ctx, callerPC, e, message)
+}
+
+// Same as TracemCtx, but the descriptive message can have formatted values
+func TracefCtx(ctx context.Context, e error, format string, args ...interface{}) error {
+	return addTrace(ctx, callerPC, e, format, args...)
+}
+
+// This function registers an extractor of a field (i.e. Field_RequestID) from the context, used by the TraceCtx functions.
+// The extractors run synchronously, on the order they were registered. The returned function unregisters the extractor
+func RegisterExtractor(field string, extractor Extractor) (unregister func()) {
+
+	if extractor == nil {
+		return func() {}
+	}
+
+	extractorsMutex.Lock()
+	defer extractorsMutex.Unlock()
+
+	extractorsID++
+	id := extractorsID
+	current, _ := extractors.Load().([]registeredExtractor)
+	updated := make([]registeredExtractor, len(current), len(current)+1)
+	copy(updated, current)
+	extractors.Store(append(updated, registeredExtractor{id: id, field: field, extractor: extractor}))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeExtractor(id) })
+	}
+}
+
+func removeExtractor(id uint64) {
+
+	extractorsMutex.Lock()
+	defer extractorsMutex.Unlock()
+
+	current, _ := extractors.Load().([]registeredExtractor)
+	updated := make([]registeredExtractor, 0, len(current))
+	for _, item := range current {
+		if item.id != id {
+			updated = append(updated, item)
+		}
+	}
+	extractors.Store(updated)
+}
+
+// This function returns a copy of the context carrying the field, that will be attached by the TraceCtx
+// functions without any extractor (useful when the value is not already on the context)
+func WithField(ctx context.Context, field string, value string) context.Context {
+
+	current, _ := ctx.Value(contextKey{}).(map[string]string)
+	fields := make(map[string]string, len(current)+1)
+	for key, item := range current {
+		fields[key] = item
+	}
+	fields[field] = value
+
+	return context.WithValue(ctx, contextKey{}, fields)
+}
+
+// This function attaches the fields to the error (i.e. rebuilt from a formatted output), without tracing it.
+// The errors that are not enhanced are wrapped without frames. The fields already attached are kept
+func AttachFields(err error, fields map[string]string) error {
+
+	if err == nil || len(fields) == 0 {
+		return err
+	}
+
+	enhancedErr, ok := err.(*enhancedError)
+	if !ok {
+		enhancedErr = &enhancedError{err: err}
+	}
+	setFields(enhancedErr, fields)
+
+	return enhancedErr
+}
+
+// This function attaches the fields of the context to the enhanced error. The fields already attached
+// (i.e. by a deeper trace) are kept
+func addFields(ctx context.Context, err *enhancedError) {
+	setFields(err, extractFields(ctx))
+}
+
+func setFields(err *enhancedError, fields map[string]string) {
+
+	for field, value := range fields {
+		if _, exists := err.fields[field]; exists {
+			continue
+		}
+		if err.fields == nil {
+			err.fields = make(map[string]string)
+		}
+		err.fields[field] = value
+	}
+}
+
+// This function returns the fields of the context, set with WithField or by the registered extractors
+func extractFields(ctx context.Context) map[string]string {
+
+	fields, _ := ctx.Value(contextKey{}).(map[string]string)
+	current, _ := extractors.Load().([]registeredExtractor)
+	if len(current) == 0 {
+		return fields
+	}
+
+	result := make(map[string]string, len(fields)+len(current))
+	for field, value := range fields {
+		result[field] = value
+	}
+	for _, item := range current {
+		if _, exists := result[item.field]; exists {
+			continue
+		}
+		if value, ok := runExtractor(ctx, item.extractor); ok {
+			result[item.field] = value
+		}
+	}
+
+	return result
+}
+
+func runExtractor(ctx context.Context, extractor Extractor) (value string, ok bool) {
+	defer func() {
+		if recover() != nil {
+			value, ok = "", false
+		}
+	}()
+	return extractor(ctx)
+}
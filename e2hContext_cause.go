@@ -0,0 +1,32 @@
+//go:build go1.20
+// +build go1.20
+
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"context"
+)
+
+// This function returns the cause of the context cancellation (see context.Cause), and true, if the error is
+// the context one and the cancellation has a different cause (i.e. the traced error that canceled a Group).
+// The errors are only compared with the context one, whose type is comparable
+func contextCause(ctx context.Context, err error) (error, bool) {
+
+	ctxErr := ctx.Err()
+	if ctxErr == nil || err != ctxErr {
+		return nil, false
+	}
+	if cause := context.Cause(ctx); cause != nil && cause != ctxErr {
+		return cause, true
+	}
+
+	return nil, false
+}
+
+// This function returns a copy of the context, that is canceled with a cause (see context.WithCancelCause)
+func withCancelCause(ctx context.Context) (context.Context, func(error)) {
+	return context.WithCancelCause(ctx)
+}
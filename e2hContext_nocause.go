@@ -0,0 +1,24 @@
+//go:build !go1.20
+// +build !go1.20
+
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"context"
+)
+
+// Before Go 1.20, the cancellations have no cause, so the error is never replaced
+func contextCause(ctx context.Context, err error) (error, bool) {
+	return nil, false
+}
+
+// Before Go 1.20, the cancellations have no cause, so the context is canceled ignoring it
+func withCancelCause(ctx context.Context) (context.Context, func(error)) {
+
+	ctx, cancel := context.WithCancel(ctx)
+
+	return ctx, func(error) { cancel() }
+}
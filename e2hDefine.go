@@ -0,0 +1,83 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import "fmt"
+
+// Interface implemented by the definitions (see Define) and its instances, to get the code and message template
+type Definer interface {
+	// This function returns the code of the definition (i.e. "USER_NOT_FOUND")
+	Code() string
+	// This function returns the message template of the definition (i.e. "user %s not found")
+	Template() string
+}
+
+// Entity with a kind of error, declared once at package level and instantiated with its New function.
+// The instances match the definition with errors.Is
+type Definition struct {
+	code     string
+	template string
+}
+
+// Entity with an instance of a definition, with its message rendered
+type definedError struct {
+	definition *Definition
+	message    string
+}
+
+// This function declares a kind of error, with a code and a message template (with the fmt verbs of the
+// New arguments): var ErrUserNotFound = e2h.Define("USER_NOT_FOUND", "user %s not found")
+func Define(code string, template string) *Definition {
+	return &Definition{
+		code:     code,
+		template: template,
+	}
+}
+
+// This function returns the message template, so the definition could be used as any other sentinel error
+func (d *Definition) Error() string {
+	return d.template
+}
+
+// This function returns the code of the definition
+func (d *Definition) Code() string {
+	return d.code
+}
+
+// This function returns the message template of the definition
+func (d *Definition) Template() string {
+	return d.template
+}
+
+// This function returns a new instance of the definition, with the template rendered with the arguments,
+// already traced: return ErrUserNotFound.New(name). Its cause matches the definition with errors.Is
+func (d *Definition) New(args ...interface{}) error {
+
+	message := d.template
+	if args != nil {
+		message = fmt.Sprintf(d.template, args...)
+	}
+
+	return addTrace(nil, callerPC, &definedError{definition: d, message: message}, "")
+}
+
+// This function returns the rendered message
+func (e *definedError) Error() string {
+	return e.message
+}
+
+// This function returns the code of the definition
+func (e *definedError) Code() string {
+	return e.definition.code
+}
+
+// This function returns the message template of the definition
+func (e *definedError) Template() string {
+	return e.definition.template
+}
+
+// This function returns true if the target is the definition of the error (see errors.Is)
+func (e *definedError) Is(target error) bool {
+	return target == e.definition
+}
@@ -0,0 +1,66 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"regexp"
+)
+
+var (
+	quotedRegexp = regexp.MustCompile(`"[^"]*"|'[^']*'`)
+	numberRegexp = regexp.MustCompile(`\b0[xX][0-9a-fA-F]+\b|\d+`)
+)
+
+// This function returns a stable key that groups the same logical error, across deploys.
+// It hashes the cause type and message template (numbers and quoted values are ignored, or the code and
+// template of its definition, see Define, or the format of Errorf),
+// the function names of the stack, and the context messages templates (without the Tracef arguments).
+// The line numbers and file paths are not taken into account
+func Fingerprint(err error) string {
+
+	if err == nil {
+		return ""
+	}
+
+	hash := sha256.New()
+
+	cause := err
+	switch err := err.(type) {
+	case *enhancedError:
+		cause = err.err
+		for _, item := range err.frames {
+			details := item.resolve()
+			template := item.format
+			if item.details != nil {
+				template = messageTemplate(details.Message)
+			}
+			fmt.Fprintf(hash, "%s\x00%s\x00", details.FuncName, template)
+		}
+	case EnhancedError:
+		cause = err.Cause()
+		for _, item := range err.Stack() {
+			fmt.Fprintf(hash, "%s\x00%s\x00", item.FuncName, messageTemplate(item.Message))
+		}
+	}
+	if definer, ok := cause.(Definer); ok {
+		// The instances of a definition are grouped by its code and template, whatever the arguments are
+		fmt.Fprintf(hash, "%T\x00%s\x00%s\x00", cause, definer.Code(), definer.Template())
+	} else if templater, ok := cause.(interface{ Template() string }); ok {
+		// The errors created by Errorf are grouped by its format
+		fmt.Fprintf(hash, "%T\x00%s\x00", cause, templater.Template())
+	} else {
+		fmt.Fprintf(hash, "%T\x00%s\x00", cause, messageTemplate(cause.Error()))
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)[:8])
+}
+
+// This function replaces the variable parts of a message (numbers and quoted values) with placeholders
+func messageTemplate(message string) string {
+	message = quotedRegexp.ReplaceAllString(message, "\"?\"")
+	return numberRegexp.ReplaceAllString(message, "#")
+}
@@ -0,0 +1,22 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+// This function calls the addTrace with the error of a (value, error) result, in order to trace it inline:
+// return e2h.Trace2(repo.Get(id)). The value is returned unchanged. A message can't be added inline
+// (Go doesn't allow extra arguments after a multi-value call), use Tracem or Tracef on the error instead
+func Trace2[T any](v T, err error) (T, error) {
+	return v, addTrace(nil, callerPC, err, "")
+}
+
+// This function returns the value of a (value, error) result, or panics with the traced error if it's not nil:
+// config := e2h.Must(loadConfig(path)). Intended for initialization code, where the error can't be handled
+func Must[T any](v T, err error) T {
+
+	if err != nil {
+		panic(addTrace(nil, callerPC, err, ""))
+	}
+
+	return v
+}
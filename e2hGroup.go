@@ -0,0 +1,154 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Interface implemented by the enhanced errors, to get the call stack of the goroutine that spawned the worker
+// which returned the error (see Group)
+type SpawnStacker interface {
+	// This function returns the call stack at the spawning call site, from the deepest frame, or nil if it was not captured
+	SpawnedFrom() []StackDetails
+}
+
+// Entity that runs a group of goroutines working on subtasks of a common task, compatible with the errgroup package
+// (golang.org/x/sync/errgroup). The call stack of the spawning call site is attached to the enhanced errors returned by
+// the workers, so they keep the path that spawned them (see SpawnStacker). The errors that are not enhanced are returned
+// unchanged, so they can be compared as with errgroup (i.e. group.Wait() == ErrX). A zero Group is valid, has no limit
+// on the number of active goroutines, and does not cancel on error
+type Group struct {
+	wg      sync.WaitGroup
+	sem     chan struct{}
+	errOnce sync.Once
+	err     error
+	cancel  func(error)
+}
+
+// This function returns a new Group and an associated Context derived from ctx.
+// The derived Context is canceled the first time a function passed to Go returns an error or the first time Wait returns.
+// Since Go 1.20, the cancellation cause is the traced error (see TraceCtx)
+func GroupWithContext(ctx context.Context) (*Group, context.Context) {
+
+	ctx, cancel := withCancelCause(ctx)
+
+	return &Group{cancel: cancel}, ctx
+}
+
+// This function calls the given function in a new goroutine. It blocks until the new goroutine can be added
+// without the number of active goroutines exceeding the configured limit.
+// The first call to return a non-nil error cancels the group's context, if any; its error will be returned by Wait
+func (g *Group) Go(f func() error) {
+
+	if g.sem != nil {
+		g.sem <- struct{}{}
+	}
+	g.spawn(f, spawnSite())
+}
+
+// This function calls the given function in a new goroutine only if the number of active goroutines
+// in the group is currently below the configured limit. The return value reports whether the goroutine was started
+func (g *Group) TryGo(f func() error) bool {
+
+	if g.sem != nil {
+		select {
+		case g.sem <- struct{}{}:
+		default:
+			return false
+		}
+	}
+	g.spawn(f, spawnSite())
+
+	return true
+}
+
+// This function limits the number of active goroutines in this group to at most n. A negative value indicates no limit.
+// The limit must not be modified while any goroutines in the group are active
+func (g *Group) SetLimit(n int) {
+
+	if n < 0 {
+		g.sem = nil
+		return
+	}
+	if len(g.sem) != 0 {
+		panic(fmt.Errorf("e2h: modify limit while %v goroutines in the group are still active", len(g.sem)))
+	}
+	g.sem = make(chan struct{}, n)
+}
+
+// This function blocks until all function calls from the Go method have returned, then returns the first non-nil error (if any) from them
+func (g *Group) Wait() error {
+
+	g.wg.Wait()
+	if g.cancel != nil {
+		g.cancel(g.err)
+	}
+
+	return g.err
+}
+
+// This function runs the worker, attaching the call stack of the spawning call site to its error
+func (g *Group) spawn(f func() error, spawnedFrom []uintptr) {
+
+	g.wg.Add(1)
+	go func() {
+		defer g.done()
+
+		if err := f(); err != nil {
+			g.errOnce.Do(func() {
+				g.err = withSpawnSite(err, spawnedFrom)
+				if g.cancel != nil {
+					g.cancel(g.err)
+				}
+			})
+		}
+	}()
+}
+
+func (g *Group) done() {
+
+	if g.sem != nil {
+		<-g.sem
+	}
+	g.wg.Done()
+}
+
+// This function calls the given function in a new goroutine, and returns a channel that receives its error (with
+// the call stack of the spawning call site attached, if it's enhanced) or nil, once it returns
+func Go(f func() error) <-chan error {
+
+	result := make(chan error, 1)
+	spawnedFrom := spawnSite()
+	go func() {
+		result <- withSpawnSite(f(), spawnedFrom)
+	}()
+
+	return result
+}
+
+// This function returns the program counters of the call stack of the spawning call site, according to the capture level.
+// It must be called directly from the function that spawns the goroutine
+func spawnSite() []uintptr {
+
+	if GetLevel() < Level_Frames {
+		return nil
+	}
+
+	return callStackPCs()
+}
+
+// This function attaches the spawning call site to the error, if it's enhanced. The other errors are
+// returned unchanged. If the error already has a spawning call site (i.e. from an inner group), it's kept
+func withSpawnSite(err error, spawnedFrom []uintptr) error {
+
+	enhancedErr, ok := err.(*enhancedError)
+	if ok && len(enhancedErr.spawnedFrom) == 0 {
+		enhancedErr.spawnedFrom = spawnedFrom
+	}
+
+	return err
+}
@@ -0,0 +1,93 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Entity with the details of a trace operation, received by the hooks
+type TraceEvent struct {
+	//The enhanced error created or updated. Its last stack item is the frame just added
+	Err EnhancedError
+	//Sets if the enhanced error was created (true) or a frame was appended to an existing one (false)
+	New bool
+}
+
+// Function called on every trace operation
+type Hook func(event TraceEvent)
+
+type registeredHook struct {
+	id   uint64
+	hook Hook
+}
+
+var (
+	hooksMutex sync.Mutex
+	hooksID    uint64
+	// Current []registeredHook. Replaced (never modified) on every change, to be read without locking
+	hooks atomic.Value
+)
+
+// This function registers a hook that will be called whenever a trace creates an enhanced error
+// or appends a frame to an existing one. The hooks run synchronously, and its panics are recovered
+// and ignored. The returned function unregisters the hook
+func RegisterHook(hook Hook) (unregister func()) {
+
+	if hook == nil {
+		return func() {}
+	}
+
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+
+	hooksID++
+	id := hooksID
+	current, _ := hooks.Load().([]registeredHook)
+	updated := make([]registeredHook, len(current), len(current)+1)
+	copy(updated, current)
+	hooks.Store(append(updated, registeredHook{id: id, hook: hook}))
+
+	var once sync.Once
+	return func() {
+		once.Do(func() { removeHook(id) })
+	}
+}
+
+func removeHook(id uint64) {
+
+	hooksMutex.Lock()
+	defer hooksMutex.Unlock()
+
+	current, _ := hooks.Load().([]registeredHook)
+	updated := make([]registeredHook, 0, len(current))
+	for _, item := range current {
+		if item.id != id {
+			updated = append(updated, item)
+		}
+	}
+	hooks.Store(updated)
+}
+
+// This function calls every registered hook with the event
+func runHooks(err EnhancedError, isNew bool) {
+
+	current, _ := hooks.Load().([]registeredHook)
+	if len(current) == 0 {
+		return
+	}
+
+	event := TraceEvent{Err: err, New: isNew}
+	for _, item := range current {
+		runHook(item.hook, event)
+	}
+}
+
+func runHook(hook Hook, event TraceEvent) {
+	defer func() {
+		recover()
+	}()
+	hook(event)
+}
@@ -0,0 +1,45 @@
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"sync/atomic"
+)
+
+// Level sets how much information is captured by the trace functions
+type Level int32
+
+// Allowed capture levels.
+const (
+	//The trace functions returns the error unchanged
+	Level_Off Level = iota
+	//Only the context messages are captured, without frames
+	Level_Messages
+	//The caller frame is captured on each trace (default)
+	Level_Frames
+	//Same as Level_Frames, plus the full call stack at the first trace (see CallStacker)
+	Level_FullStack
+)
+
+// Max depth of the call stack captured with Level_FullStack
+const maxCallStackDepth = 64
+
+var currentLevel = int32(Level_Frames)
+
+// This function sets the capture level of the trace functions. The errors already traced
+// keeps (and formats) the information captured at that time
+func SetLevel(level Level) {
+	atomic.StoreInt32(&currentLevel, int32(level))
+}
+
+// This function returns the current capture level
+func GetLevel() Level {
+	return Level(atomic.LoadInt32(&currentLevel))
+}
+
+// Interface implemented by the enhanced errors, to get the full call stack captured with Level_FullStack
+type CallStacker interface {
+	// This function returns the call stack at the first trace, from the deepest frame, or nil if it was not captured
+	CallStack() []StackDetails
+}
@@ -0,0 +1,58 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+Package e2h its the package of the Enhanced Error Handling module
+*/
+package e2h
+
+import (
+	"log/slog"
+	"sort"
+	"strconv"
+)
+
+// This function returns the error as a log/slog group (see slog.LogValuer), with its message, cause,
+// code and template (if it's an instance of a definition), fields and frames (by index, from the deepest).
+// The values are not redacted, use the e2hslog handler in order to apply the formatter params
+func (e *enhancedError) LogValue() slog.Value {
+
+	attrs := []slog.Attr{
+		slog.String("message", e.Error()),
+		slog.String("cause", e.err.Error()),
+	}
+	if definer, ok := e.err.(Definer); ok {
+		attrs = append(attrs, slog.String("code", definer.Code()), slog.String("template", definer.Template()))
+	}
+
+	if len(e.fields) > 0 {
+		names := make([]string, 0, len(e.fields))
+		for name := range e.fields {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		fields := make([]slog.Attr, 0, len(names))
+		for _, name := range names {
+			fields = append(fields, slog.String(name, e.fields[name]))
+		}
+		attrs = append(attrs, slog.Attr{Key: "fields", Value: slog.GroupValue(fields...)})
+	}
+
+	frames := make([]slog.Attr, 0, len(e.frames))
+	for i, item := range e.Stack() {
+		frame := make([]slog.Attr, 0, 4)
+		if len(item.FuncName) > 0 {
+			frame = append(frame, slog.String("func", item.FuncName))
+		}
+		if len(item.File) > 0 {
+			frame = append(frame, slog.String("file", item.File), slog.Int("line", item.Line))
+		}
+		if len(item.Message) > 0 {
+			frame = append(frame, slog.String("context", item.Message))
+		}
+		frames = append(frames, slog.Attr{Key: strconv.Itoa(i), Value: slog.GroupValue(frame...)})
+	}
+	attrs = append(attrs, slog.Attr{Key: "frames", Value: slog.GroupValue(frames...)})
+
+	return slog.GroupValue(attrs...)
+}
@@ -4,54 +4,182 @@ Package e2h its the package of the Enhanced Error Handling module
 package e2h
 
 import (
+	"context"
+	"errors"
 	"fmt"
-	"runtime"
 )
 
 // This function calls the addTrace in order to create or add stack info
 func Trace(e error) error {
-	return addTrace(e, "")
+	return addTrace(nil, callerPC, e, "")
 }
 
 // Same as Trace, but adding a descriptive message
 func Tracem(e error, message string) error {
-	return addTrace(e, message)
+	return addTrace(nil, callerPC, e, message)
 }
 
 // Same as Tracem, but the descriptive message can have formatted values
 func Tracef(e error, format string, args ...interface{}) error {
-	return addTrace(e, format, args...)
+	return addTrace(nil, callerPC, e, format, args...)
 }
 
-// This is the private function that creates the first EnhancedError
-// with info or add the new info to the existing one
-func addTrace(err error, format string, args ...interface{}) error {
+// This function returns a new error with the provided message, already traced: e2h.New(msg) is the same
+// as e2h.Trace(errors.New(msg)). Not intended for package level errors (i.e. sentinels), because its frames
+// are appended on every trace
+func New(message string) error {
+	return addTrace(nil, callerPC, errors.New(message), "")
+}
+
+// Same as New, but the message can have formatted values. As in fmt.Errorf, the %w verb wraps the
+// error argument, so it's reachable through the cause chain (see errors.Is and errors.As).
+// The format is kept as the template of the cause, so the Fingerprint ignores the arguments
+func Errorf(format string, args ...interface{}) error {
+	return addTrace(nil, callerPC, &formattedError{err: fmt.Errorf(format, args...), template: format}, "")
+}
+
+// Entity with an error created by Errorf, and its format
+type formattedError struct {
+	err      error
+	template string
+}
+
+// This function returns the rendered message
+func (e *formattedError) Error() string {
+	return e.err.Error()
+}
+
+// This function returns the format of the message
+func (e *formattedError) Template() string {
+	return e.template
+}
+
+// This function returns the error wrapped with the %w verb (if any)
+func (e *formattedError) Unwrap() error {
+	return errors.Unwrap(e.err)
+}
 
-	if err == nil {
+// This function returns true if the rendered error matches the target (see errors.Is), including
+// the errors wrapped with many %w verbs
+func (e *formattedError) Is(target error) bool {
+	return errors.Is(e.err, target)
+}
+
+// Same as Is, but for errors.As
+func (e *formattedError) As(target interface{}) bool {
+	return errors.As(e.err, target)
+}
+
+// This function traces the error pointed by err (i.e. a named result) only if it's not nil, with the frame
+// of the function that deferred the call: defer e2h.Wrap(&err, "loading config %s", path).
+// The frame line is the one where the function returned (or its closing brace, depending on how the compiler implements the defer)
+func Wrap(err *error, format string, args ...interface{}) {
+
+	if err == nil || *err == nil {
+		return
+	}
+	*err = addTrace(nil, deferCallerPC, *err, format, args...)
+}
+
+// This function returns the value recovered from a panic as an error (if it's not one already), traced with the
+// frame where the panic happened, plus the call stack of the panicking goroutine (see CallStacker). It must be
+// called directly from the deferred function that recovered it: defer func() { err = e2h.TracePanic(recover()) }()
+func TracePanic(recovered interface{}) error {
+
+	if recovered == nil {
 		return nil
 	}
+	err, ok := recovered.(error)
+	if !ok {
+		err = fmt.Errorf("%v", recovered)
+	}
+
+	pcs := panicStackPCs()
+	panicPC := func() uintptr {
+		if len(pcs) == 0 {
+			return 0
+		}
+		return pcs[0]
+	}
+	traced := addTrace(nil, panicPC, err, "recovered from panic")
+	if enhancedErr, ok := traced.(*enhancedError); ok && len(enhancedErr.callStack) == 0 && GetLevel() >= Level_Frames {
+		enhancedErr.callStack = pcs
+	}
+
+	return traced
+}
+
+// This is the private function that creates the first EnhancedError
+// with info or add the new info to the existing one. The context (if any)
+// provides the request-scoped metadata and the cause of its cancellation,
+// and the caller function returns the program counter of the traced frame
+func addTrace(ctx context.Context, caller func() uintptr, err error, format string, args ...interface{}) error {
+
+	level := GetLevel()
+	if err == nil || level == Level_Off {
+		return err
+	}
+	// Context error (i.e. context.Canceled) replaced by the cause of the cancellation, kept to match it with errors.Is
+	var contextErr error
+	if ctx != nil {
+		if cause, replaced := contextCause(ctx, err); replaced {
+			contextErr, err = err, cloneError(cause)
+		}
+	}
 
 	message := format
 	if args != nil {
 		message = fmt.Sprintf(format, args...)
 	}
-	pc, file, line, _ := runtime.Caller(2)
-	info := StackDetails{
-		File:     file,
-		Line:     line,
-		FuncName: runtime.FuncForPC(pc).Name(),
-		Message:  message,
+	info := frame{
+		message: message,
+		format:  format,
+	}
+	if level >= Level_Frames {
+		info.pc = caller()
 	}
 
-	switch err.(type) {
-	case EnhancedError:
-		err.(*enhancedError).stack = append(err.(*enhancedError).stack, info)
+	switch err := err.(type) {
+	case *enhancedError:
+		err.frames = append(err.frames, info)
+		if contextErr != nil {
+			err.contextErr = contextErr
+		}
+		if ctx != nil {
+			addFields(ctx, err)
+		}
+		runHooks(err, false)
 		return err
 
 	default:
-		return &enhancedError{
-			err:   err,
-			stack: append(make([]StackDetails, 0), info),
+		enhancedErr := &enhancedError{
+			err:        err,
+			frames:     append(make([]frame, 0, 4), info),
+			contextErr: contextErr,
+		}
+		if level >= Level_FullStack {
+			enhancedErr.callStack = callStackPCs()
 		}
+		if ctx != nil {
+			addFields(ctx, enhancedErr)
+		}
+		runHooks(enhancedErr, true)
+		return enhancedErr
 	}
 }
+
+// This function returns a copy of the enhanced error, so it can be traced without modifying the original one
+// (i.e. the cause of a context cancellation, shared by several goroutines)
+func cloneError(err error) error {
+
+	enhancedErr, ok := err.(*enhancedError)
+	if !ok {
+		return err
+	}
+
+	clone := *enhancedErr
+	clone.frames = append(make([]frame, 0, len(enhancedErr.frames)+4), enhancedErr.frames...)
+	clone.fields = enhancedErr.Fields()
+
+	return &clone
+}
@@ -0,0 +1,75 @@
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+var (
+	benchErr    = fmt.Errorf("This is a standard error")
+	benchResult error
+	benchOutput string
+)
+
+func benchDeepest() error {
+	return e2h.Trace(benchErr)
+}
+
+func benchMiddle() error {
+	return e2h.Tracem(benchDeepest(), "Error executing benchDeepest()")
+}
+
+func benchTop() error {
+	return e2h.Tracef(benchMiddle(), "Error executing [%s] function", "benchMiddle()")
+}
+
+func BenchmarkTrace(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchResult = e2h.Trace(benchErr)
+	}
+}
+
+func BenchmarkTracem(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchResult = e2h.Tracem(benchErr, "Error wrapped with additional info")
+	}
+}
+
+func BenchmarkTracef(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchResult = e2h.Tracef(benchErr, "This is the %dnd. stack level", 2)
+	}
+}
+
+func BenchmarkTrace_ThreeLevels(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchResult = benchTop()
+	}
+}
+
+func BenchmarkTrace_ThreeLevels_Stack(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchResult = benchTop()
+		_ = benchResult.(e2h.EnhancedError).Stack()
+	}
+}
+
+func BenchmarkTrace_ThreeLevels_JSONFormat(b *testing.B) {
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{}
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		benchOutput = jsonFormatter.Format(benchTop(), params)
+	}
+}
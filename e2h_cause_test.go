@@ -0,0 +1,25 @@
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCause(t *testing.T) {
+
+	// Setup
+	cause := errors.New("the cause")
+	rebuilt := e2h.Rebuild(cause, []e2h.StackDetails{{FuncName: "pkg.foo"}})
+
+	// Execute & Check
+	require.Equal(t, cause, e2h.Cause(e2h.Tracem(e2h.Trace(cause), "info")))
+	require.Equal(t, cause, e2h.Cause(e2h.Trace(rebuilt)))
+	require.Equal(t, cause, e2h.Cause(cause))
+	require.Nil(t, e2h.Cause(nil))
+}
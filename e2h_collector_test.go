@@ -0,0 +1,161 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollector_Snapshot_GroupsByFingerprint(t *testing.T) {
+
+	// Setup
+	collector := e2h.NewCollector(10)
+
+	// Execute
+	for i := 0; i < 3; i++ {
+		collector.Collect(loadUser(i))
+	}
+	collector.Collect(loadGroup(1))
+	collector.Collect(nil)
+	snapshot := collector.Snapshot()
+
+	// Check
+	require.Len(t, snapshot, 2)
+	require.Equal(t, uint64(3), snapshot[0].Count)
+	require.Equal(t, e2h.Fingerprint(loadUser(0)), snapshot[0].Fingerprint)
+	require.Equal(t, "user 0 not found in \"users_0\"", snapshot[0].Sample.(e2h.EnhancedError).Cause().Error())
+	require.Len(t, snapshot[0].Stack, 1)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.loadUser", snapshot[0].Stack[0].FuncName)
+	require.False(t, snapshot[0].LastSeen.Before(snapshot[0].FirstSeen))
+	require.Equal(t, uint64(1), snapshot[1].Count)
+}
+
+func TestCollector_Collect_EvictsLeastRecentlySeen(t *testing.T) {
+
+	// Setup
+	collector := e2h.NewCollector(2)
+	errA := fmt.Errorf("error A")
+	errB := fmt.Errorf("error B")
+	errC := fmt.Errorf("error C")
+
+	// Execute
+	collector.Collect(errA)
+	collector.Collect(errB)
+	collector.Collect(errA)
+	collector.Collect(errC)
+	snapshot := collector.Snapshot()
+
+	// Check
+	require.Len(t, snapshot, 2)
+	require.Equal(t, e2h.Fingerprint(errA), snapshot[0].Fingerprint)
+	require.Equal(t, e2h.Fingerprint(errC), snapshot[1].Fingerprint)
+
+	collector.Reset()
+	require.Empty(t, collector.Snapshot())
+}
+
+func TestCollector_Top_Concurrent(t *testing.T) {
+
+	// Setup
+	collector := e2h.NewCollector(0)
+	var wg sync.WaitGroup
+
+	// Execute
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			collector.Collect(fmt.Errorf("error %c", 'A'+rune(i%5)))
+		}(i)
+	}
+	wg.Wait()
+
+	// Check
+	top := collector.Top(3)
+	require.Len(t, top, 3)
+	for _, item := range top {
+		require.Equal(t, uint64(4), item.Count)
+	}
+	require.Len(t, collector.Top(-1), 5)
+}
+
+func TestFormatReport_Raw(t *testing.T) {
+
+	// Setup
+	collector := e2h.NewCollector(10)
+	collector.Collect(loadUser(1))
+	collector.Collect(loadUser(2))
+	collector.Collect(fmt.Errorf("This is a standard error"))
+
+	// Execute
+	output, err := e2hformat.FormatReport(collector.Top(20), e2hformat.Format_Raw, e2hformat.Params{})
+
+	// Check
+	require.Nil(t, err)
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 2)
+	require.True(t, strings.HasPrefix(lines[0], fmt.Sprintf("#1 - 2 occurrence(s) (fingerprint: %s, first seen: ", e2h.Fingerprint(loadUser(1)))))
+	require.Contains(t, lines[0], "): user 1 not found in \"users_1\"; github.com/cdleo/go-e2h_test.loadUser (")
+	require.True(t, strings.HasPrefix(lines[1], "#2 - 1 occurrence(s)"))
+	require.True(t, strings.HasSuffix(lines[1], "): This is a standard error"))
+}
+
+func TestFormatReport_TracedAfterCollect(t *testing.T) {
+
+	// Setup
+	collector := e2h.NewCollector(10)
+	err := loadUser(1)
+	collector.Collect(err)
+	e2h.Tracem(err, "handling request")
+
+	// Execute
+	output, formatErr := e2hformat.FormatReport(collector.Top(20), e2hformat.Format_Raw, e2hformat.Params{})
+
+	// Check
+	require.Nil(t, formatErr)
+	require.NotContains(t, output, "handling request")
+	require.Len(t, collector.Top(20)[0].Sample.(e2h.EnhancedError).Stack(), 1)
+	require.Len(t, err.(e2h.EnhancedError).Stack(), 2)
+}
+
+func TestFormatReport_JSON(t *testing.T) {
+
+	// Setup
+	collector := e2h.NewCollector(10)
+	collector.Collect(loadUser(1))
+	collector.Collect(loadUser(2))
+
+	// Execute
+	output, err := e2hformat.FormatReport(collector.Top(20), e2hformat.Format_JSON, e2hformat.Params{})
+
+	// Check
+	require.Nil(t, err)
+	var report []struct {
+		Rank        int    `json:"rank"`
+		Fingerprint string `json:"fingerprint"`
+		Count       uint64 `json:"count"`
+		Details     struct {
+			Err string `json:"error"`
+		} `json:"details"`
+	}
+	require.Nil(t, json.Unmarshal([]byte(output), &report))
+	require.Len(t, report, 1)
+	require.Equal(t, 1, report[0].Rank)
+	require.Equal(t, uint64(2), report[0].Count)
+	require.Equal(t, "user 1 not found in \"users_1\"", report[0].Details.Err)
+
+	_, err = e2hformat.FormatReport(nil, e2hformat.Format(99), e2hformat.Params{})
+	require.NotNil(t, err)
+}
@@ -0,0 +1,89 @@
+//go:build go1.20
+// +build go1.20
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceCtx_ContextCause(t *testing.T) {
+
+	// Setup
+	stdErr := errors.New("connection refused")
+	cause := e2h.Tracem(stdErr, "connecting to the database")
+	ctx, cancel := context.WithCancelCause(context.Background())
+	cancel(cause)
+
+	// Execute
+	err := e2h.TraceCtx(ctx, ctx.Err())
+
+	// Check
+	require.Equal(t, stdErr, e2h.Cause(err))
+	require.True(t, errors.Is(err, context.Canceled))
+	require.True(t, errors.Is(err, stdErr))
+	require.False(t, errors.Is(cause, context.Canceled))
+	require.Len(t, err.(e2h.EnhancedError).Stack(), 2)
+	require.Len(t, cause.(e2h.EnhancedError).Stack(), 1)
+	require.Equal(t, context.Canceled, e2h.Cause(e2h.TraceCtx(context.Background(), context.Canceled)))
+}
+
+func TestGroup_ContextCause(t *testing.T) {
+
+	// Setup
+	stdErr := errors.New("connection refused")
+	group, ctx := e2h.GroupWithContext(context.Background())
+	canceled := make(chan error, 1)
+
+	// Execute
+	group.Go(func() error {
+		<-ctx.Done()
+		canceled <- e2h.TracemCtx(ctx, ctx.Err(), "waiting for the database")
+		return nil
+	})
+	group.Go(func() error { return e2h.Trace(stdErr) })
+	err := group.Wait()
+
+	// Check
+	require.Equal(t, stdErr, e2h.Cause(err))
+	require.Len(t, err.(e2h.EnhancedError).Stack(), 1)
+	tracedCancel := <-canceled
+	require.Equal(t, stdErr, e2h.Cause(tracedCancel))
+	require.True(t, errors.Is(tracedCancel, context.Canceled))
+	require.Len(t, tracedCancel.(e2h.EnhancedError).Stack(), 2)
+	require.Equal(t, "waiting for the database", tracedCancel.(e2h.EnhancedError).Stack()[1].Message)
+}
+
+// Error with an uncomparable type, so it can't be compared with ==
+type multiErr []error
+
+func (m multiErr) Error() string {
+	return fmt.Sprintf("%d errors occurred", len(m))
+}
+
+func TestTraceCtx_UncomparableError(t *testing.T) {
+
+	// Setup
+	stdErr := multiErr{errors.New("name is required"), errors.New("age is required")}
+	canceledCtx, cancel := context.WithCancelCause(context.Background())
+	cancel(errors.New("shutting down"))
+
+	// Execute
+	err := e2h.TracefCtx(context.Background(), stdErr, "validating user %d", 10)
+	canceledErr := e2h.TraceCtx(canceledCtx, stdErr)
+
+	// Check
+	require.Equal(t, "2 errors occurred: validating user 10", err.Error())
+	require.Equal(t, stdErr, e2h.Cause(err))
+	require.Equal(t, stdErr, e2h.Cause(canceledErr))
+	require.False(t, errors.Is(canceledErr, context.Canceled))
+}
@@ -0,0 +1,80 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+type tenantKey struct{}
+
+func TestTraceCtx_Fields(t *testing.T) {
+
+	// Setup
+	unregister := e2h.RegisterExtractor(e2h.Field_Tenant, func(ctx context.Context) (string, bool) {
+		tenant, ok := ctx.Value(tenantKey{}).(string)
+		return tenant, ok
+	})
+	defer unregister()
+	defer e2h.RegisterExtractor(e2h.Field_User, func(ctx context.Context) (string, bool) { panic("broken extractor") })()
+	ctx := e2h.WithField(context.WithValue(context.Background(), tenantKey{}, "acme"), e2h.Field_RequestID, "req-1")
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	// Execute
+	err := e2h.TracefCtx(ctx, errors.New("This is a standard error"), "loading user %d", 10)
+	err = e2h.TraceCtx(e2h.WithField(context.Background(), e2h.Field_RequestID, "other"), err)
+
+	// Check
+	stack := err.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 2)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestTraceCtx_Fields", stack[0].FuncName)
+	require.Equal(t, "loading user 10", stack[0].Message)
+	require.Equal(t, map[string]string{e2h.Field_RequestID: "req-1", e2h.Field_Tenant: "acme"}, err.(e2h.Fielder).Fields())
+	require.Contains(t, rawFormatter.Format(err, e2hformat.Params{}), "; fields: request_id=req-1, tenant=acme;")
+	require.Contains(t, rawFormatter.Format(err, e2hformat.Params{Beautify: true}), "\nFields:\n\trequest_id=req-1\n\ttenant=acme")
+	require.Contains(t, jsonFormatter.Format(err, e2hformat.Params{}), ",\"fields\":{\"request_id\":\"req-1\",\"tenant\":\"acme\"}")
+}
+
+func TestTraceCtx_Unregister(t *testing.T) {
+
+	// Setup
+	unregister := e2h.RegisterExtractor(e2h.Field_TraceParent, func(ctx context.Context) (string, bool) {
+		return "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", true
+	})
+
+	// Execute
+	withTraceParent := e2h.TracemCtx(context.Background(), errors.New("This is a standard error"), "info")
+	unregister()
+	withoutFields := e2h.TracemCtx(context.Background(), errors.New("This is a standard error"), "info")
+
+	// Check
+	require.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", withTraceParent.(e2h.Fielder).Fields()[e2h.Field_TraceParent])
+	require.Nil(t, withoutFields.(e2h.Fielder).Fields())
+}
+
+func TestTraceCtx_ParseJSON(t *testing.T) {
+
+	// Setup
+	ctx := e2h.WithField(context.Background(), e2h.Field_RequestID, "req-1")
+	err := e2h.TraceCtx(ctx, errors.New("This is a standard error"))
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	// Execute
+	parsed, parseErr := e2hformat.ParseJSON(jsonFormatter.Format(err, e2hformat.Params{}), e2hformat.Params{})
+
+	// Check
+	require.Nil(t, parseErr)
+	require.Equal(t, map[string]string{e2h.Field_RequestID: "req-1"}, parsed.(e2h.Fielder).Fields())
+	require.Equal(t, jsonFormatter.Format(err, e2hformat.Params{}), jsonFormatter.Format(parsed, e2hformat.Params{}))
+}
@@ -0,0 +1,89 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	errUserNotFound = e2h.Define("USER_NOT_FOUND", "user %s not found")
+	errUserLocked   = e2h.Define("USER_LOCKED", "user locked")
+)
+
+func findUser(name string) error {
+	return errUserNotFound.New(name)
+}
+
+func TestDefine(t *testing.T) {
+
+	// Execute
+	err := e2h.Tracem(findUser("john"), "logging in")
+
+	// Check
+	require.Equal(t, "user john not found", err.Error())
+	require.True(t, errors.Is(err, errUserNotFound))
+	require.False(t, errors.Is(err, errUserLocked))
+	require.False(t, errors.Is(err, e2h.Define("USER_NOT_FOUND", "user %s not found")))
+
+	stack := err.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 2)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.findUser", stack[0].FuncName)
+	require.True(t, strings.HasSuffix(stack[0].File, "e2h_define_test.go"))
+	require.Empty(t, stack[0].Message)
+
+	definer, ok := e2h.Cause(err).(e2h.Definer)
+	require.True(t, ok)
+	require.Equal(t, "USER_NOT_FOUND", definer.Code())
+	require.Equal(t, "user %s not found", definer.Template())
+}
+
+func TestDefine_WithoutArgs(t *testing.T) {
+
+	// Execute
+	err := errUserLocked.New()
+
+	// Check
+	require.Equal(t, "user locked", err.Error())
+	require.Equal(t, "user locked", errUserLocked.Error())
+	require.True(t, errors.Is(err, errUserLocked))
+}
+
+func TestDefine_FormatterOutput(t *testing.T) {
+
+	// Setup
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	err := findUser("john")
+
+	// Execute
+	raw := rawFormatter.Format(err, e2hformat.Params{})
+	beautified := rawFormatter.Format(err, e2hformat.Params{Beautify: true})
+	json := jsonFormatter.Format(err, e2hformat.Params{})
+
+	// Check
+	require.True(t, strings.HasPrefix(raw, "user john not found; github.com/cdleo/go-e2h_test.findUser ("))
+	require.True(t, strings.HasSuffix(raw, "; definition: code=USER_NOT_FOUND, template=user %s not found;"))
+	require.True(t, strings.HasSuffix(beautified, "\nDefinition:\n\tcode=USER_NOT_FOUND\n\ttemplate=user %s not found"))
+	require.True(t, strings.HasPrefix(json, "{\"error\":\"user john not found\",\"code\":\"USER_NOT_FOUND\",\"template\":\"user %s not found\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.findUser\""))
+
+	parsed, parseErr := e2hformat.ParseRaw(beautified, e2hformat.Params{Beautify: true})
+	require.Nil(t, parseErr)
+	require.Equal(t, "user john not found", parsed.Error())
+	require.Len(t, parsed.Stack(), 1)
+}
+
+func TestDefine_Fingerprint(t *testing.T) {
+	require.Equal(t, e2h.Fingerprint(findUser("john")), e2h.Fingerprint(findUser("mary")))
+	require.NotEqual(t, e2h.Fingerprint(findUser("john")), e2h.Fingerprint(e2h.Trace(errors.New("user john not found"))))
+}
@@ -1,3 +1,6 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
 /*
 Package e2h_test its the test package of the Enhanced Error Handling module
 */
@@ -80,14 +83,14 @@ func ExampleEnhancedError() {
 	// Just cause => TheError
 	//
 	// Full info (inverted stack) =>
-	// github.com/cdleo/go-e2h_test.ExampleEnhancedError (e2h_example_test.go:47) [Error executing [bar()] function]; github.com/cdleo/go-e2h_test.bar (e2h_example_test.go:33) [Error executing foo()]; github.com/cdleo/go-e2h_test.foo (e2h_example_test.go:24); TheError;
+	// github.com/cdleo/go-e2h_test.ExampleEnhancedError (e2h_example_test.go:50) [Error executing [bar()] function]; github.com/cdleo/go-e2h_test.bar (e2h_example_test.go:36) [Error executing foo()]; github.com/cdleo/go-e2h_test.foo (e2h_example_test.go:27); TheError;
 	//
 	// Full info (beautified / inverted stack) =>
-	// github.com/cdleo/go-e2h_test.ExampleEnhancedError (e2h_example_test.go:47)
+	// github.com/cdleo/go-e2h_test.ExampleEnhancedError (e2h_example_test.go:50)
 	// 	Error executing [bar()] function
-	// github.com/cdleo/go-e2h_test.bar (e2h_example_test.go:33)
+	// github.com/cdleo/go-e2h_test.bar (e2h_example_test.go:36)
 	// 	Error executing foo()
-	// github.com/cdleo/go-e2h_test.foo (e2h_example_test.go:24)
+	// github.com/cdleo/go-e2h_test.foo (e2h_example_test.go:27)
 	// TheError
 	//
 	// **** JSON Formatter ****
@@ -95,7 +98,7 @@ func ExampleEnhancedError() {
 	// Just cause => {"error":"TheError"}
 	//
 	// Full info =>
-	// {"error":"TheError","stack_trace":[{"func":"github.com/cdleo/go-e2h_test.foo","caller":"e2h_example_test.go:24"},{"func":"github.com/cdleo/go-e2h_test.bar","caller":"e2h_example_test.go:33","context":"Error executing foo()"},{"func":"github.com/cdleo/go-e2h_test.ExampleEnhancedError","caller":"e2h_example_test.go:47","context":"Error executing [bar()] function"}]}
+	// {"error":"TheError","stack_trace":[{"func":"github.com/cdleo/go-e2h_test.foo","caller":"e2h_example_test.go:27"},{"func":"github.com/cdleo/go-e2h_test.bar","caller":"e2h_example_test.go:36","context":"Error executing foo()"},{"func":"github.com/cdleo/go-e2h_test.ExampleEnhancedError","caller":"e2h_example_test.go:50","context":"Error executing [bar()] function"}]}
 	//
 	// Full info (beautified) =>
 	// {
@@ -103,16 +106,16 @@ func ExampleEnhancedError() {
 	// 	"stack_trace": [
 	// 		{
 	// 			"func": "github.com/cdleo/go-e2h_test.foo",
-	// 			"caller": "e2h_example_test.go:24"
+	// 			"caller": "e2h_example_test.go:27"
 	// 		},
 	// 		{
 	// 			"func": "github.com/cdleo/go-e2h_test.bar",
-	// 			"caller": "e2h_example_test.go:33",
+	// 			"caller": "e2h_example_test.go:36",
 	// 			"context": "Error executing foo()"
 	// 		},
 	// 		{
 	// 			"func": "github.com/cdleo/go-e2h_test.ExampleEnhancedError",
-	// 			"caller": "e2h_example_test.go:47",
+	// 			"caller": "e2h_example_test.go:50",
 	// 			"context": "Error executing [bar()] function"
 	// 		}
 	// 	]
@@ -0,0 +1,89 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func loadUser(id int) error {
+	return e2h.Tracef(fmt.Errorf("user %d not found in \"users_%d\"", id, id%4), "loading user %d", id)
+}
+
+func loadGroup(id int) error {
+	return e2h.Tracef(fmt.Errorf("user %d not found in \"users_%d\"", id, id%4), "loading user %d", id)
+}
+
+func TestFingerprint_SameLogicalError(t *testing.T) {
+
+	// Setup
+	first := e2h.Trace(loadUser(10))
+	second := e2h.Trace(
+		loadUser(2379))
+
+	// Execute & Check
+	require.Len(t, e2h.Fingerprint(first), 16)
+	require.Equal(t, e2h.Fingerprint(first), e2h.Fingerprint(second))
+}
+
+func TestFingerprint_DifferentErrors(t *testing.T) {
+
+	// Setup
+	fromUser := e2h.Trace(loadUser(10))
+	fromGroup := e2h.Trace(loadGroup(10))
+	otherCause := e2h.Trace(e2h.Tracef(fmt.Errorf("user %d is disabled", 10), "loading user %d", 10))
+
+	// Execute & Check
+	require.NotEqual(t, e2h.Fingerprint(fromUser), e2h.Fingerprint(fromGroup))
+	require.NotEqual(t, e2h.Fingerprint(fromUser), e2h.Fingerprint(otherCause))
+	require.NotEqual(t, e2h.Fingerprint(fromUser), e2h.Fingerprint(fmt.Errorf("user 10 not found in \"users_2\"")))
+	require.Equal(t, e2h.Fingerprint(fmt.Errorf("user 10 not found")), e2h.Fingerprint(fmt.Errorf("user 20 not found")))
+	require.Empty(t, e2h.Fingerprint(nil))
+}
+
+func findOwner(name string) error {
+	return e2h.Errorf("owner %s not found", name)
+}
+
+func TestFingerprint_Errorf(t *testing.T) {
+
+	// Setup
+	alice := findOwner("alice")
+	bob := findOwner("bob")
+
+	// Execute & Check
+	require.Equal(t, e2h.Fingerprint(alice), e2h.Fingerprint(bob))
+	require.NotEqual(t, e2h.Fingerprint(alice), e2h.Fingerprint(e2h.Errorf("owner %q not found", "alice")))
+	require.Equal(t, "owner alice not found", alice.Error())
+}
+
+func TestEnhancedError_Formatters_Format_Fingerprint(t *testing.T) {
+
+	// Setup
+	enhancedErr := loadUser(10)
+	fingerprint := e2h.Fingerprint(enhancedErr)
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{
+		IncludeFingerprint: true,
+	}
+
+	// Execute
+	outputRaw := rawFormatter.Format(enhancedErr, params)
+	outputJSON := jsonFormatter.Format(enhancedErr, params)
+	outputStd := jsonFormatter.Format(fmt.Errorf("This is a standard error"), params)
+
+	// Check
+	require.Contains(t, outputRaw, fmt.Sprintf("user 10 not found in \"users_2\" (fingerprint: %s);", fingerprint))
+	require.Contains(t, outputJSON, fmt.Sprintf("{\"error\":\"user 10 not found in \\\"users_2\\\"\",\"fingerprint\":\"%s\",", fingerprint))
+	require.Equal(t, fmt.Sprintf("{\"error\":\"This is a standard error\",\"fingerprint\":\"%s\",\"stack_trace\":[]}", e2h.Fingerprint(fmt.Errorf("This is a standard error"))), outputStd)
+}
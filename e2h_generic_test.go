@@ -0,0 +1,60 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+func parsePort(value string) (int, error) {
+	return e2h.Trace2(strconv.Atoi(value))
+}
+
+func TestTrace2(t *testing.T) {
+
+	// Execute
+	port, err := parsePort("8080")
+	_, invalid := parsePort("http")
+
+	// Check
+	require.Nil(t, err)
+	require.Equal(t, 8080, port)
+
+	stack := invalid.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 1)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.parsePort", stack[0].FuncName)
+	require.True(t, strings.HasSuffix(stack[0].File, "e2h_generic_test.go"))
+	require.Empty(t, stack[0].Message)
+	require.IsType(t, &strconv.NumError{}, e2h.Cause(invalid))
+}
+
+func TestMust(t *testing.T) {
+
+	// Setup
+	stdErr := errors.New("This is a standard error")
+
+	// Execute
+	value := e2h.Must("value", nil)
+	var recovered interface{}
+	func() {
+		defer func() { recovered = recover() }()
+		e2h.Must(0, stdErr)
+	}()
+
+	// Check
+	require.Equal(t, "value", value)
+	err, ok := recovered.(error)
+	require.True(t, ok)
+	require.Equal(t, stdErr, e2h.Cause(err))
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestMust.func1", err.(e2h.EnhancedError).Stack()[0].FuncName)
+}
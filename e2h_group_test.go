@@ -0,0 +1,117 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+const groupTestFunc = "github.com/cdleo/go-e2h_test.TestGroup_TracedError"
+
+func TestGroup_TracedError(t *testing.T) {
+
+	// Setup
+	var group e2h.Group
+
+	// Execute
+	group.Go(func() error { return nil })
+	group.Go(func() error { return loadUser(10) })
+	err := group.Wait()
+
+	// Check
+	require.NotNil(t, err)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.loadUser", err.(e2h.EnhancedError).Stack()[0].FuncName)
+	spawnedFrom := err.(e2h.SpawnStacker).SpawnedFrom()
+	require.NotEmpty(t, spawnedFrom)
+	require.Equal(t, groupTestFunc, spawnedFrom[0].FuncName)
+	require.True(t, strings.HasSuffix(spawnedFrom[0].File, "e2h_group_test.go"))
+
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	require.Contains(t, rawFormatter.Format(err, e2hformat.Params{Beautify: true}), "\nSpawned from:\n\t"+groupTestFunc+" (")
+	require.Contains(t, rawFormatter.Format(err, e2hformat.Params{}), "; spawned from: "+groupTestFunc+" (")
+	require.Contains(t, jsonFormatter.Format(err, e2hformat.Params{}), "\"spawned_from\":[{\"func\":\""+groupTestFunc+"\"")
+}
+
+func TestGroup_StandardError(t *testing.T) {
+
+	// Setup
+	stdErr := errors.New("This is a standard error")
+	group, ctx := e2h.GroupWithContext(context.Background())
+
+	// Execute
+	group.Go(func() error { return stdErr })
+	<-ctx.Done()
+	err := group.Wait()
+
+	// Check
+	require.True(t, err == stdErr)
+	require.True(t, <-e2h.Go(func() error { return stdErr }) == stdErr)
+}
+
+func TestGroup_Limit(t *testing.T) {
+
+	// Setup
+	var group e2h.Group
+	var count int32
+	release := make(chan struct{})
+	group.SetLimit(1)
+
+	// Execute
+	started := group.TryGo(func() error {
+		<-release
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+	rejected := !group.TryGo(func() error { return nil })
+	close(release)
+	group.Go(func() error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+
+	// Check
+	require.True(t, started)
+	require.True(t, rejected)
+	require.Nil(t, group.Wait())
+	require.Equal(t, int32(2), atomic.LoadInt32(&count))
+}
+
+func TestGo(t *testing.T) {
+
+	// Execute
+	success := e2h.Go(func() error { return nil })
+	failure := e2h.Go(func() error { return loadUser(10) })
+
+	// Check
+	require.Nil(t, <-success)
+	err := <-failure
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestGo", err.(e2h.SpawnStacker).SpawnedFrom()[0].FuncName)
+}
+
+func TestGroup_LevelMessages(t *testing.T) {
+
+	// Setup
+	e2h.SetLevel(e2h.Level_Messages)
+	defer e2h.SetLevel(e2h.Level_Frames)
+	stdErr := errors.New("This is a standard error")
+	var group e2h.Group
+
+	// Execute
+	group.Go(func() error { return stdErr })
+
+	// Check
+	require.Equal(t, stdErr, group.Wait())
+}
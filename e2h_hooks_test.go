@@ -0,0 +1,81 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterHook_ReceivesEvents(t *testing.T) {
+
+	// Setup
+	events := make([]e2h.TraceEvent, 0)
+	frames := make([]e2h.StackDetails, 0)
+	unregister := e2h.RegisterHook(func(event e2h.TraceEvent) {
+		events = append(events, event)
+		stack := event.Err.Stack()
+		frames = append(frames, stack[len(stack)-1])
+	})
+	defer unregister()
+
+	// Execute
+	enhancedErr := e2h.Tracem(fmt.Errorf("This is a standard error"), "first")
+	enhancedErr = e2h.Tracef(enhancedErr, "%s", "second")
+	e2h.Trace(nil)
+
+	// Check
+	require.Len(t, events, 2)
+	require.True(t, events[0].New)
+	require.False(t, events[1].New)
+	require.Equal(t, enhancedErr, events[1].Err)
+	require.Equal(t, "first", frames[0].Message)
+	require.Equal(t, "second", frames[1].Message)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestRegisterHook_ReceivesEvents", frames[1].FuncName)
+}
+
+func TestRegisterHook_Unregister(t *testing.T) {
+
+	// Setup
+	firstCount, secondCount := 0, 0
+	unregisterFirst := e2h.RegisterHook(func(event e2h.TraceEvent) { firstCount++ })
+	unregisterSecond := e2h.RegisterHook(func(event e2h.TraceEvent) { secondCount++ })
+	defer unregisterSecond()
+
+	// Execute
+	e2h.Trace(fmt.Errorf("This is a standard error"))
+	unregisterFirst()
+	unregisterFirst()
+	e2h.Trace(fmt.Errorf("This is a standard error"))
+
+	// Check
+	require.Equal(t, 1, firstCount)
+	require.Equal(t, 2, secondCount)
+}
+
+func TestRegisterHook_PanicsAreIsolated(t *testing.T) {
+
+	// Setup
+	called := false
+	unregisterPanic := e2h.RegisterHook(func(event e2h.TraceEvent) { panic("hook failure") })
+	defer unregisterPanic()
+	unregister := e2h.RegisterHook(func(event e2h.TraceEvent) { called = true })
+	defer unregister()
+
+	// Execute
+	var enhancedErr error
+	require.NotPanics(t, func() {
+		enhancedErr = e2h.Tracem(fmt.Errorf("This is a standard error"), "still traced")
+	})
+
+	// Check
+	require.True(t, called)
+	require.Equal(t, "This is a standard error: still traced", enhancedErr.Error())
+}
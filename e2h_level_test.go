@@ -0,0 +1,88 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetLevel_Off(t *testing.T) {
+
+	// Setup
+	e2h.SetLevel(e2h.Level_Off)
+	defer e2h.SetLevel(e2h.Level_Frames)
+	stdErr := fmt.Errorf("This is a standard error")
+
+	// Execute
+	output := e2h.Tracem(stdErr, "Error wrapped with additional info")
+
+	// Check
+	require.Equal(t, e2h.Level_Off, e2h.GetLevel())
+	require.Equal(t, stdErr, output)
+}
+
+func TestSetLevel_Messages(t *testing.T) {
+
+	// Setup
+	e2h.SetLevel(e2h.Level_Messages)
+	defer e2h.SetLevel(e2h.Level_Frames)
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	// Execute
+	enhancedErr := e2h.Tracem(fmt.Errorf("This is a standard error"), "Error wrapped with additional info")
+	enhancedErr = e2h.Trace(enhancedErr)
+
+	// Check
+	require.Equal(t, "This is a standard error: Error wrapped with additional info", enhancedErr.Error())
+	require.Equal(t, []e2h.StackDetails{{Message: "Error wrapped with additional info"}, {}}, enhancedErr.(e2h.EnhancedError).Stack())
+	require.Equal(t, "This is a standard error; <unknown> [Error wrapped with additional info]; <unknown>;", rawFormatter.Format(enhancedErr, e2hformat.Params{}))
+	require.Equal(t, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"context\":\"Error wrapped with additional info\"},{}]}", jsonFormatter.Format(enhancedErr, e2hformat.Params{}))
+}
+
+func TestSetLevel_FullStack(t *testing.T) {
+
+	// Setup
+	e2h.SetLevel(e2h.Level_FullStack)
+	defer e2h.SetLevel(e2h.Level_Frames)
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	// Execute
+	enhancedErr := e2h.Trace(loadUser(10))
+
+	// Check
+	callStack := enhancedErr.(e2h.CallStacker).CallStack()
+	require.True(t, len(callStack) >= 3)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.loadUser", callStack[0].FuncName)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestSetLevel_FullStack", callStack[1].FuncName)
+	require.Equal(t, "testing.tRunner", callStack[2].FuncName)
+	require.Len(t, enhancedErr.(e2h.EnhancedError).Stack(), 2)
+
+	outputRaw := rawFormatter.Format(enhancedErr, e2hformat.Params{Beautify: true, InvertCallstack: true})
+	require.Contains(t, outputRaw, "\nCall stack:\n\t")
+	require.True(t, strings.HasSuffix(outputRaw, "\n\tgithub.com/cdleo/go-e2h_test.loadUser ("+callStack[0].File+fmt.Sprintf(":%d)", callStack[0].Line)))
+
+	outputJSON := jsonFormatter.Format(enhancedErr, e2hformat.Params{})
+	require.Contains(t, outputJSON, fmt.Sprintf(",\"call_stack\":[{\"func\":\"github.com/cdleo/go-e2h_test.loadUser\",\"caller\":\"%s:%d\"},", callStack[0].File, callStack[0].Line))
+}
+
+func TestSetLevel_Frames_NoCallStack(t *testing.T) {
+
+	// Execute
+	enhancedErr := e2h.Trace(fmt.Errorf("This is a standard error"))
+
+	// Check
+	require.Equal(t, e2h.Level_Frames, e2h.GetLevel())
+	require.Nil(t, enhancedErr.(e2h.CallStacker).CallStack())
+}
@@ -0,0 +1,79 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnhancedError_RawFormatter_Format_ModulePathHidden(t *testing.T) {
+
+	// Setup
+	_, _, line, _ := runtime.Caller(0)
+	enhancedErr := e2h.Tracem(fmt.Errorf("This is a standard error"), "Error wrapped with additional info")
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	params := e2hformat.Params{
+		PathHidingMethod: e2hformat.HidingMethod_Module,
+	}
+
+	// Execute
+	output := rawFormatter.Format(enhancedErr, params)
+
+	// Check
+	require.Equal(t, fmt.Sprintf("This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_RawFormatter_Format_ModulePathHidden (github.com/cdleo/go-e2h/e2h_modulepath_test.go:%d) [Error wrapped with additional info];", line+1), output)
+}
+
+func TestEnhancedError_JSONFormatter_Format_ModulePathHidden(t *testing.T) {
+
+	// Setup
+	_, thisFile, _, _ := runtime.Caller(0)
+	moduleDir := filepath.Dir(thisFile)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{
+		PathHidingMethod: e2hformat.HidingMethod_Module,
+	}
+
+	tests := []struct {
+		name     string
+		file     string
+		funcName string
+		want     string
+	}{
+		{"goroot", "/usr/local/go/src/runtime/proc.go", "runtime.main", "$GOROOT/src/runtime/proc.go"},
+		{"goroot_trimpath", "net/http/server.go", "net/http.(*conn).serve", "$GOROOT/src/net/http/server.go"},
+		{"module_cache", "/home/user/go/pkg/mod/github.com/!burnt!sushi/toml@v1.2.0/decode.go", "github.com/BurntSushi/toml.Decode", "github.com/BurntSushi/toml@v1.2.0/decode.go"},
+		{"module_cache_trimpath", "github.com/BurntSushi/toml@v1.2.0/decode.go", "github.com/BurntSushi/toml.Decode", "github.com/BurntSushi/toml@v1.2.0/decode.go"},
+		{"module", "/build/src/internal/svc/handler.go", "example.com/app/internal/svc.(*Handler).Serve.func1", "example.com/app/internal/svc/handler.go"},
+		{"module_trimpath", "example.com/app/internal/svc/handler.go", "example.com/app/internal/svc.(*Handler).Serve.func1", "example.com/app/internal/svc/handler.go"},
+		{"dotted_package", "/build/vendor/gopkg.in/yaml.v3/decode.go", "gopkg.in/yaml%2ev3.(*parser).parse", "gopkg.in/yaml.v3/decode.go"},
+		{"dotted_package_unescaped", "/build/vendor/gopkg.in/yaml.v3/decode.go", "gopkg.in/yaml.v3.(*parser).parse", "gopkg.in/yaml.v3/decode.go"},
+		{"dotted_package_func", "/build/vendor/gopkg.in/yaml.v3/yaml.go", "gopkg.in/yaml.v3.Marshal", "gopkg.in/yaml.v3/yaml.go"},
+		{"main_package", filepath.Join(moduleDir, "cmd", "tool", "main.go"), "main.main", "github.com/cdleo/go-e2h/cmd/tool/main.go"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enhancedErr := &fakeEnhancedError{
+				err:   fmt.Errorf("This is a standard error"),
+				stack: []e2h.StackDetails{{File: tt.file, Line: 10, FuncName: tt.funcName}},
+			}
+
+			// Execute
+			output := jsonFormatter.Format(enhancedErr, params)
+
+			// Check
+			require.Equal(t, fmt.Sprintf("{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"%s\",\"caller\":\"%s:10\"}]}", tt.funcName, tt.want), output)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+
+	// Execute
+	err := e2h.New("This is a new error")
+
+	// Check
+	require.Equal(t, "This is a new error", err.Error())
+	stack := err.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 1)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestNew", stack[0].FuncName)
+	require.True(t, strings.HasSuffix(stack[0].File, "e2h_new_test.go"))
+	require.Empty(t, stack[0].Message)
+}
+
+func TestErrorf_Wrapping(t *testing.T) {
+
+	// Setup
+	stdErr := errors.New("This is a standard error")
+	enhancedErr := e2h.Tracem(os.ErrNotExist, "opening file")
+
+	// Execute
+	wrappedStd := e2h.Errorf("loading user %d: %w", 10, stdErr)
+	wrappedEnhanced := e2h.Errorf("loading config: %w", enhancedErr)
+	formatted := e2h.Errorf("loading user %d", 10)
+
+	// Check
+	require.Equal(t, "loading user 10: This is a standard error", wrappedStd.Error())
+	require.True(t, errors.Is(wrappedStd, stdErr))
+	require.Equal(t, "loading config: file does not exist: opening file", wrappedEnhanced.Error())
+	require.True(t, errors.Is(wrappedEnhanced, os.ErrNotExist))
+	require.Equal(t, "loading user 10", formatted.Error())
+	require.Nil(t, errors.Unwrap(e2h.Cause(formatted)))
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestErrorf_Wrapping", wrappedStd.(e2h.EnhancedError).Stack()[0].FuncName)
+}
+
+func TestNew_FormatterOutput(t *testing.T) {
+
+	// Setup
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	// Execute (on the same line, so the frames are the same)
+	newErr, errorfErr, tracedErr := e2h.New("user 10 not found"), e2h.Errorf("user %d not found", 10), e2h.Trace(fmt.Errorf("user %d not found", 10))
+
+	// Check
+	for _, params := range []e2hformat.Params{{}, {Beautify: true}, {InvertCallstack: true}} {
+		expectedRaw := rawFormatter.Format(tracedErr, params)
+		require.Equal(t, expectedRaw, rawFormatter.Format(newErr, params))
+		require.Equal(t, expectedRaw, rawFormatter.Format(errorfErr, params))
+
+		expectedJSON := jsonFormatter.Format(tracedErr, params)
+		require.Equal(t, expectedJSON, jsonFormatter.Format(newErr, params))
+		require.Equal(t, expectedJSON, jsonFormatter.Format(errorfErr, params))
+	}
+	require.True(t, strings.HasPrefix(rawFormatter.Format(newErr, e2hformat.Params{}), "user 10 not found; github.com/cdleo/go-e2h_test.TestNew_FormatterOutput ("))
+}
+
+func TestNew_LevelOff(t *testing.T) {
+
+	// Setup
+	e2h.SetLevel(e2h.Level_Off)
+	defer e2h.SetLevel(e2h.Level_Frames)
+
+	// Execute
+	err := e2h.Errorf("loading user %d", 10)
+
+	// Check
+	_, ok := err.(e2h.EnhancedError)
+	require.False(t, ok)
+	require.Equal(t, "loading user 10", err.Error())
+}
@@ -0,0 +1,41 @@
+//go:build e2h_nocaller
+// +build e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnhancedError_NoCaller(t *testing.T) {
+
+	// Setup
+	stdErr := errors.New("This is a standard error")
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	// Execute
+	enhancedErr := e2h.Tracem(e2h.Tracef(stdErr, "loading user %d", 10), "serving request")
+
+	// Check
+	require.Equal(t, "This is a standard error: loading user 10", enhancedErr.Error())
+	require.Equal(t, stdErr, e2h.Cause(enhancedErr))
+	require.True(t, errors.Is(enhancedErr, stdErr))
+	require.Equal(t, []e2h.StackDetails{{Message: "loading user 10"}, {Message: "serving request"}}, enhancedErr.(e2h.EnhancedError).Stack())
+
+	require.Equal(t, "This is a standard error [loading user 10]", rawFormatter.Source(enhancedErr))
+	require.Equal(t, "This is a standard error; <unknown> [loading user 10]; <unknown> [serving request];",
+		rawFormatter.Format(enhancedErr, e2hformat.Params{}))
+	require.Equal(t, "This is a standard error\n<unknown>\n\tloading user 10\n<unknown>\n\tserving request",
+		rawFormatter.Format(enhancedErr, e2hformat.Params{Beautify: true}))
+	require.Equal(t, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"context\":\"loading user 10\"},{\"context\":\"serving request\"}]}",
+		jsonFormatter.Format(enhancedErr, e2hformat.Params{}))
+}
@@ -0,0 +1,63 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+type account struct {
+	balance int
+}
+
+func withdraw(target *account, amount int) {
+	target.balance -= amount
+}
+
+func recoverPanic(fn func()) (err error) {
+	defer func() {
+		err = e2h.TracePanic(recover())
+	}()
+	fn()
+	return nil
+}
+
+func TestTracePanic(t *testing.T) {
+
+	// Execute
+	err := recoverPanic(func() { panic("something went wrong") })
+
+	// Check
+	require.Equal(t, "something went wrong: recovered from panic", err.Error())
+	stack := err.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 1)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestTracePanic.func1", stack[0].FuncName)
+	require.True(t, strings.HasSuffix(stack[0].File, "e2h_panic_test.go"))
+	callStack := err.(e2h.CallStacker).CallStack()
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestTracePanic.func1", callStack[0].FuncName)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.recoverPanic", callStack[1].FuncName)
+}
+
+func TestTracePanic_RuntimeError(t *testing.T) {
+
+	// Execute
+	err := recoverPanic(func() { withdraw(nil, 10) })
+
+	// Check
+	var runtimeErr interface{ RuntimeError() }
+	require.True(t, errors.As(err, &runtimeErr))
+	require.Equal(t, "github.com/cdleo/go-e2h_test.withdraw", err.(e2h.EnhancedError).Stack()[0].FuncName)
+}
+
+func TestTracePanic_NoPanic(t *testing.T) {
+	require.Nil(t, recoverPanic(func() {}))
+}
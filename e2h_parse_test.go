@@ -0,0 +1,95 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_RoundTrip(t *testing.T) {
+
+	// Setup
+	err := e2h.Trace(loadUser(10))
+	cases := []struct {
+		format e2hformat.Format
+		params e2hformat.Params
+		parse  func(string, e2hformat.Params) (e2h.EnhancedError, error)
+	}{
+		{e2hformat.Format_JSON, e2hformat.Params{}, e2hformat.ParseJSON},
+		{e2hformat.Format_JSON, e2hformat.Params{Beautify: true, InvertCallstack: true}, e2hformat.ParseJSON},
+		{e2hformat.Format_Raw, e2hformat.Params{Beautify: true}, e2hformat.ParseRaw},
+		{e2hformat.Format_Raw, e2hformat.Params{Beautify: true, InvertCallstack: true}, e2hformat.ParseRaw},
+		{e2hformat.Format_Raw, e2hformat.Params{Beautify: true, SourceContextLines: 1}, e2hformat.ParseRaw},
+	}
+
+	for _, c := range cases {
+		formatter, _ := e2hformat.NewFormatter(c.format)
+		output := formatter.Format(err, c.params)
+
+		// Execute
+		parsed, parseErr := c.parse(output, c.params)
+
+		// Check
+		require.Nil(t, parseErr)
+		require.Equal(t, output, formatter.Format(parsed, c.params))
+		require.Equal(t, err.(e2h.EnhancedError).Cause().Error(), parsed.Cause().Error())
+		require.Equal(t, err.(e2h.EnhancedError).Stack(), parsed.Stack())
+	}
+}
+
+func TestParse_TraceParsedError(t *testing.T) {
+
+	// Setup
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	parsed, parseErr := e2hformat.ParseJSON(jsonFormatter.Format(loadUser(10), e2hformat.Params{}), e2hformat.Params{})
+	require.Nil(t, parseErr)
+
+	// Execute
+	err := e2h.Tracem(parsed, "retrying")
+
+	// Check
+	stack := err.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 2)
+	require.Equal(t, "loading user 10", stack[0].Message)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestParse_TraceParsedError", stack[1].FuncName)
+	require.Equal(t, "retrying", stack[1].Message)
+	require.Equal(t, "user 10 not found in \"users_2\": loading user 10", err.Error())
+}
+
+func TestParse_RawWithFingerprint(t *testing.T) {
+
+	// Setup
+	params := e2hformat.Params{Beautify: true, IncludeFingerprint: true}
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+
+	// Execute
+	parsed, parseErr := e2hformat.ParseRaw(rawFormatter.Format(loadUser(10), params), params)
+
+	// Check
+	require.Nil(t, parseErr)
+	require.Equal(t, "user 10 not found in \"users_2\"", parsed.Cause().Error())
+	require.Len(t, parsed.Stack(), 1)
+}
+
+func TestParse_Invalid(t *testing.T) {
+
+	// Execute
+	_, errJSON := e2hformat.ParseJSON(`{"error":"cause"}`, e2hformat.Params{})
+	_, errSyntax := e2hformat.ParseJSON(`{"error":`, e2hformat.Params{})
+	_, errNotBeautified := e2hformat.ParseRaw("cause; pkg.foo (foo.go:1);", e2hformat.Params{})
+	_, errOrphanContext := e2hformat.ParseRaw("pkg.foo (foo.go:1)\n\tcontext\ncause", e2hformat.Params{Beautify: true})
+
+	// Check
+	require.NotNil(t, errJSON)
+	require.NotNil(t, errSyntax)
+	require.NotNil(t, errNotBeautified)
+	require.NotNil(t, errOrphanContext)
+}
@@ -0,0 +1,83 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"fmt"
+	"runtime"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPermalink_Templates(t *testing.T) {
+
+	require.Equal(t, "https://github.com/cdleo/go-e2h/blob/{revision}/{path}#L{line}", e2hformat.GitHubPermalink("https://github.com/cdleo/go-e2h/"))
+	require.Equal(t, "https://gitlab.com/cdleo/go-e2h/-/blob/{revision}/{path}#L{line}", e2hformat.GitLabPermalink("https://gitlab.com/cdleo/go-e2h"))
+	require.Equal(t, "https://gitea.com/cdleo/go-e2h/src/commit/{revision}/{path}#L{line}", e2hformat.GiteaPermalink("https://gitea.com/cdleo/go-e2h"))
+}
+
+func TestEnhancedError_RawFormatter_Format_Permalink(t *testing.T) {
+
+	// Setup
+	_, _, line, _ := runtime.Caller(0)
+	enhancedErr := e2h.Tracem(fmt.Errorf("This is a standard error"), "Error wrapped with additional info")
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	params := e2hformat.Params{
+		Beautify:          true,
+		PathHidingMethod:  e2hformat.HidingMethod_Module,
+		PermalinkTemplate: e2hformat.GitHubPermalink("https://github.com/cdleo/go-e2h"),
+		PermalinkRevision: "a50fd16",
+	}
+
+	// Execute
+	output := rawFormatter.Format(enhancedErr, params)
+
+	// Check
+	require.Equal(t, fmt.Sprintf("This is a standard error\ngithub.com/cdleo/go-e2h_test.TestEnhancedError_RawFormatter_Format_Permalink (github.com/cdleo/go-e2h/e2h_permalink_test.go:%d) https://github.com/cdleo/go-e2h/blob/a50fd16/e2h_permalink_test.go#L%d\n\tError wrapped with additional info", line+1, line+1), output)
+}
+
+func TestEnhancedError_JSONFormatter_Format_Permalink(t *testing.T) {
+
+	// Setup
+	_, _, line, _ := runtime.Caller(0)
+	enhancedErr := e2h.Trace(fmt.Errorf("This is a standard error"))
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{
+		PathHidingMethod:  e2hformat.HidingMethod_Module,
+		PermalinkTemplate: e2hformat.GitLabPermalink("https://gitlab.com/cdleo/go-e2h"),
+		PermalinkRevision: "a50fd16",
+	}
+
+	// Execute
+	output := jsonFormatter.Format(enhancedErr, params)
+
+	// Check
+	require.Equal(t, fmt.Sprintf("{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_JSONFormatter_Format_Permalink\",\"caller\":\"github.com/cdleo/go-e2h/e2h_permalink_test.go:%d\",\"url\":\"https://gitlab.com/cdleo/go-e2h/-/blob/a50fd16/e2h_permalink_test.go#L%d\"}]}", line+1, line+1), output)
+}
+
+func TestEnhancedError_JSONFormatter_Format_Permalink_ExternalFrame(t *testing.T) {
+
+	// Setup
+	enhancedErr := &fakeEnhancedError{
+		err:   fmt.Errorf("This is a standard error"),
+		stack: []e2h.StackDetails{{File: "/usr/local/go/src/runtime/proc.go", Line: 10, FuncName: "runtime.main"}},
+	}
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{
+		PermalinkTemplate: e2hformat.GiteaPermalink("https://gitea.com/cdleo/go-e2h"),
+		PermalinkRevision: "a50fd16",
+	}
+
+	// Execute
+	output := jsonFormatter.Format(enhancedErr, params)
+
+	// Check
+	require.NotContains(t, output, "\"url\"")
+}
@@ -0,0 +1,62 @@
+//go:build go1.21 && !e2h_nocaller
+// +build go1.21,!e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+type slogFrame struct {
+	Func    string `json:"func"`
+	File    string `json:"file"`
+	Line    int    `json:"line"`
+	Context string `json:"context"`
+}
+
+type slogError struct {
+	Message  string               `json:"message"`
+	Cause    string               `json:"cause"`
+	Code     string               `json:"code"`
+	Template string               `json:"template"`
+	Fields   map[string]string    `json:"fields"`
+	Frames   map[string]slogFrame `json:"frames"`
+}
+
+func TestLogValue(t *testing.T) {
+
+	// Setup
+	var output bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&output, nil))
+	ctx := e2h.WithField(context.Background(), e2h.Field_RequestID, "req-1")
+	err := e2h.TracemCtx(ctx, findUser("john"), "logging in")
+
+	// Execute
+	logger.Error("request failed", "err", err)
+
+	// Check
+	var entry struct {
+		Err slogError `json:"err"`
+	}
+	require.Nil(t, json.Unmarshal(output.Bytes(), &entry))
+	require.Equal(t, "user john not found", entry.Err.Message)
+	require.Equal(t, "user john not found", entry.Err.Cause)
+	require.Equal(t, "USER_NOT_FOUND", entry.Err.Code)
+	require.Equal(t, "user %s not found", entry.Err.Template)
+	require.Equal(t, map[string]string{e2h.Field_RequestID: "req-1"}, entry.Err.Fields)
+	require.Len(t, entry.Err.Frames, 2)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.findUser", entry.Err.Frames["0"].Func)
+	require.NotZero(t, entry.Err.Frames["0"].Line)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.TestLogValue", entry.Err.Frames["1"].Func)
+	require.Equal(t, "logging in", entry.Err.Frames["1"].Context)
+}
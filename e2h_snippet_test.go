@@ -0,0 +1,104 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/stretchr/testify/require"
+)
+
+// Fake EnhancedError, used to test frames pointing to unavailable files
+type fakeEnhancedError struct {
+	err   error
+	stack []e2h.StackDetails
+}
+
+func (e *fakeEnhancedError) Error() string             { return e.err.Error() }
+func (e *fakeEnhancedError) Cause() error              { return e.err }
+func (e *fakeEnhancedError) Stack() []e2h.StackDetails { return e.stack }
+
+func TestEnhancedError_RawFormatter_Format_SourceSnippet(t *testing.T) {
+
+	// Setup
+	_, _, line, _ := runtime.Caller(0)
+	enhancedErr := e2h.Trace(fmt.Errorf("This is a standard error")) // The marked line
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	params := e2hformat.Params{
+		Beautify:           true,
+		SourceContextLines: 1,
+	}
+
+	// Execute
+	output := rawFormatter.Format(enhancedErr, params)
+
+	// Check
+	lines := strings.Split(output, "\n")
+	require.Len(t, lines, 5)
+	require.Equal(t, fmt.Sprintf("\t\t  %d | \t_, _, line, _ := runtime.Caller(0)", line), lines[2])
+	require.Equal(t, fmt.Sprintf("\t\t> %d | \tenhancedErr := e2h.Trace(fmt.Errorf(\"This is a standard error\")) // The marked line", line+1), lines[3])
+	require.Equal(t, fmt.Sprintf("\t\t  %d | \trawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)", line+2), lines[4])
+
+	// Not beautified outputs doesn't include the snippet
+	params.Beautify = false
+	require.NotContains(t, rawFormatter.Format(enhancedErr, params), "The marked line")
+}
+
+func TestEnhancedError_JSONFormatter_Format_SourceSnippet(t *testing.T) {
+
+	// Setup
+	_, _, line, _ := runtime.Caller(0)
+	enhancedErr := e2h.Trace(fmt.Errorf("This is a standard error")) // The marked line
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{
+		SourceContextLines: 2,
+	}
+
+	// Execute
+	output := jsonFormatter.Format(enhancedErr, params)
+
+	// Check
+	var details struct {
+		Stack []struct {
+			Source []string `json:"source"`
+		} `json:"stack_trace"`
+	}
+	require.Nil(t, json.Unmarshal([]byte(output), &details))
+	require.Len(t, details.Stack, 1)
+	require.Len(t, details.Stack[0].Source, 5)
+	require.Equal(t, fmt.Sprintf("> %d | \tenhancedErr := e2h.Trace(fmt.Errorf(\"This is a standard error\")) // The marked line", line+1), details.Stack[0].Source[2])
+}
+
+func TestEnhancedError_Formatters_Format_SourceSnippet_MissingFile(t *testing.T) {
+
+	// Setup
+	enhancedErr := &fakeEnhancedError{
+		err:   fmt.Errorf("This is a standard error"),
+		stack: []e2h.StackDetails{{File: "/not/available/file.go", Line: 10, FuncName: "main.main"}},
+	}
+	rawFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_Raw)
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+	params := e2hformat.Params{
+		SourceContextLines: 3,
+		Beautify:           true,
+	}
+
+	// Execute
+	outputRaw := rawFormatter.Format(enhancedErr, params)
+	params.Beautify = false
+	outputJSON := jsonFormatter.Format(enhancedErr, params)
+
+	// Check
+	require.Equal(t, "This is a standard error\nmain.main (/not/available/file.go:10)", outputRaw)
+	require.Equal(t, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"main.main\",\"caller\":\"/not/available/file.go:10\"}]}", outputJSON)
+}
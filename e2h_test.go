@@ -1,3 +1,6 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
 /*
 Package e2h_test its the test package of the Enhanced Error Handling module
 */
@@ -115,7 +118,7 @@ func TestEnhancedError_EnhErr_RawFormatter_Format(t *testing.T) {
 	output := rawFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format (e2h_test.go:105) [Error wrapped with additional info];")
+	require.Equal(t, output, "This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format (e2h_test.go:108) [Error wrapped with additional info];")
 }
 
 func TestEnhancedError_EnhErr_JSONFormatter_Format(t *testing.T) {
@@ -134,7 +137,7 @@ func TestEnhancedError_EnhErr_JSONFormatter_Format(t *testing.T) {
 	output := jsonFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format\",\"caller\":\"e2h_test.go:124\",\"context\":\"Error wrapped with additional info\"}]}")
+	require.Equal(t, output, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format\",\"caller\":\"e2h_test.go:127\",\"context\":\"Error wrapped with additional info\"}]}")
 }
 
 func TestEnhancedError_EnhErr_RawFormatter_Format_Beautified(t *testing.T) {
@@ -154,7 +157,7 @@ func TestEnhancedError_EnhErr_RawFormatter_Format_Beautified(t *testing.T) {
 	output := rawFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "This is a standard error\ngithub.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_Beautified (e2h_test.go:143)\n\tError wrapped with additional info")
+	require.Equal(t, output, "This is a standard error\ngithub.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_Beautified (e2h_test.go:146)\n\tError wrapped with additional info")
 }
 
 func TestEnhancedError_EnhErr_JSONFormatter_Format_Beautified(t *testing.T) {
@@ -174,7 +177,7 @@ func TestEnhancedError_EnhErr_JSONFormatter_Format_Beautified(t *testing.T) {
 	output := jsonFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "{\n\t\"error\": \"This is a standard error\",\n\t\"stack_trace\": [\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_Beautified\",\n\t\t\t\"caller\": \"e2h_test.go:163\",\n\t\t\t\"context\": \"Error wrapped with additional info\"\n\t\t}\n\t]\n}")
+	require.Equal(t, output, "{\n\t\"error\": \"This is a standard error\",\n\t\"stack_trace\": [\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_Beautified\",\n\t\t\t\"caller\": \"e2h_test.go:166\",\n\t\t\t\"context\": \"Error wrapped with additional info\"\n\t\t}\n\t]\n}")
 }
 
 func TestEnhancedError_EnhErr_RawFormatter_Format_Inverted(t *testing.T) {
@@ -194,7 +197,7 @@ func TestEnhancedError_EnhErr_RawFormatter_Format_Inverted(t *testing.T) {
 	output := rawFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_Inverted (e2h_test.go:183) [Error wrapped with additional info]; This is a standard error;")
+	require.Equal(t, output, "github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_Inverted (e2h_test.go:186) [Error wrapped with additional info]; This is a standard error;")
 }
 
 func TestEnhancedError_EnhErr_JSONFormatter_Format_Inverted(t *testing.T) {
@@ -214,7 +217,7 @@ func TestEnhancedError_EnhErr_JSONFormatter_Format_Inverted(t *testing.T) {
 	output := jsonFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_Inverted\",\"caller\":\"e2h_test.go:203\",\"context\":\"Error wrapped with additional info\"}]}")
+	require.Equal(t, output, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_Inverted\",\"caller\":\"e2h_test.go:206\",\"context\":\"Error wrapped with additional info\"}]}")
 }
 
 func TestEnhancedError_EnhErr_RawFormatter_Format_FullPathHidden(t *testing.T) {
@@ -233,7 +236,7 @@ func TestEnhancedError_EnhErr_RawFormatter_Format_FullPathHidden(t *testing.T) {
 	output := rawFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_FullPathHidden (e2h_test.go:223) [Error wrapped with additional info];")
+	require.Equal(t, output, "This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_FullPathHidden (e2h_test.go:226) [Error wrapped with additional info];")
 }
 
 func TestEnhancedError_EnhErr_JSONFormatter_Format_FullPathHidden(t *testing.T) {
@@ -252,7 +255,7 @@ func TestEnhancedError_EnhErr_JSONFormatter_Format_FullPathHidden(t *testing.T)
 	output := jsonFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_FullPathHidden\",\"caller\":\"e2h_test.go:242\",\"context\":\"Error wrapped with additional info\"}]}")
+	require.Equal(t, output, "{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_FullPathHidden\",\"caller\":\"e2h_test.go:245\",\"context\":\"Error wrapped with additional info\"}]}")
 }
 
 func TestEnhancedError_EnhErr_RawFormatter_Format_PartialPathHidden(t *testing.T) {
@@ -270,7 +273,7 @@ func TestEnhancedError_EnhErr_RawFormatter_Format_PartialPathHidden(t *testing.T
 	// Execute
 	output := rawFormatter.Format(enhancedErr, params)
 
-	want := strings.ReplaceAll("This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_PartialPathHidden (<LAST_DIR>/e2h_test.go:261) [Error wrapped with additional info];",
+	want := strings.ReplaceAll("This is a standard error; github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_RawFormatter_Format_PartialPathHidden (<LAST_DIR>/e2h_test.go:264) [Error wrapped with additional info];",
 		"<LAST_DIR>", params.PathHidingValue)
 
 	// Check
@@ -292,7 +295,7 @@ func TestEnhancedError_EnhErr_JSONFormatter_Format_PartialPathHidden(t *testing.
 	// Execute
 	output := jsonFormatter.Format(enhancedErr, params)
 
-	want := strings.ReplaceAll("{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_PartialPathHidden\",\"caller\":\"<LAST_DIR>/e2h_test.go:283\",\"context\":\"Error wrapped with additional info\"}]}",
+	want := strings.ReplaceAll("{\"error\":\"This is a standard error\",\"stack_trace\":[{\"func\":\"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhErr_JSONFormatter_Format_PartialPathHidden\",\"caller\":\"<LAST_DIR>/e2h_test.go:286\",\"context\":\"Error wrapped with additional info\"}]}",
 		"<LAST_DIR>", params.PathHidingValue)
 
 	// Check
@@ -318,5 +321,5 @@ func TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces(t *testing.T
 	output := jsonFormatter.Format(enhancedErr, params)
 
 	// Check
-	require.Equal(t, output, "{\n\t\"error\": \"This is a standard error\",\n\t\"stack_trace\": [\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces\",\n\t\t\t\"caller\": \"e2h_test.go:305\",\n\t\t\t\"context\": \"Error wrapped with additional info\"\n\t\t},\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces\",\n\t\t\t\"caller\": \"e2h_test.go:306\",\n\t\t\t\"context\": \"This is the 2nd. stack level\"\n\t\t},\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces\",\n\t\t\t\"caller\": \"e2h_test.go:307\"\n\t\t}\n\t]\n}")
+	require.Equal(t, output, "{\n\t\"error\": \"This is a standard error\",\n\t\"stack_trace\": [\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces\",\n\t\t\t\"caller\": \"e2h_test.go:308\",\n\t\t\t\"context\": \"Error wrapped with additional info\"\n\t\t},\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces\",\n\t\t\t\"caller\": \"e2h_test.go:309\",\n\t\t\t\"context\": \"This is the 2nd. stack level\"\n\t\t},\n\t\t{\n\t\t\t\"func\": \"github.com/cdleo/go-e2h_test.TestEnhancedError_EnhError_JSONFormatter_Format_MultipleTraces\",\n\t\t\t\"caller\": \"e2h_test.go:310\"\n\t\t}\n\t]\n}")
 }
@@ -0,0 +1,70 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2h_test its the test package of the Enhanced Error Handling module
+*/
+package e2h_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	"github.com/stretchr/testify/require"
+)
+
+func loadConfig(path string, step int) (err error) {
+	defer e2h.Wrap(&err, "loading config %s", path)
+
+	if step == 1 {
+		return errors.New("file not found")
+	}
+	if step == 2 {
+		err = e2h.Tracem(errors.New("invalid syntax"), "parsing")
+	}
+	return
+}
+
+func loadConfigPanic() (err error) {
+	defer e2h.Wrap(&err, "loading config")
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = errors.New("recovered from panic")
+		}
+	}()
+	panic("unexpected")
+}
+
+func TestWrap(t *testing.T) {
+
+	// Execute
+	notFound := loadConfig("app.yaml", 1)
+	invalid := loadConfig("app.yaml", 2)
+	success := loadConfig("app.yaml", 0)
+	recovered := loadConfigPanic()
+
+	// Check
+	require.Nil(t, success)
+
+	stack := notFound.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 1)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.loadConfig", stack[0].FuncName)
+	require.True(t, strings.HasSuffix(stack[0].File, "e2h_wrap_test.go"))
+	require.Equal(t, "loading config app.yaml", stack[0].Message)
+	require.Equal(t, "file not found: loading config app.yaml", notFound.Error())
+
+	stack = invalid.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 2)
+	require.Equal(t, "parsing", stack[0].Message)
+	require.Equal(t, "github.com/cdleo/go-e2h_test.loadConfig", stack[1].FuncName)
+	require.Equal(t, "loading config app.yaml", stack[1].Message)
+
+	stack = recovered.(e2h.EnhancedError).Stack()
+	require.Equal(t, "github.com/cdleo/go-e2h_test.loadConfigPanic", stack[0].FuncName)
+}
+
+func TestWrap_Nil(t *testing.T) {
+	require.NotPanics(t, func() { e2h.Wrap(nil, "no error") })
+}
@@ -26,6 +26,18 @@ type Params struct {
 	PathHidingMethod formatter.HidingMethod
 	//Value to use, according to the selected 'PathHidingMethod'
 	PathHidingValue string
+	//Sets the policy used to remove sensitive values from the messages. If nil, the global policy is used
+	Redaction *RedactionPolicy
+	//Sets how many lines of source code are shown before and after each frame (0 = disabled).
+	//On the raw formatter, the snippet is only shown if the output is beautified
+	SourceContextLines int
+	//Sets the URL template used to link each frame to its source line (i.e. GitHubPermalink(repoURL)).
+	//Only the frames of the main module are linked. If empty, the links are disabled
+	PermalinkTemplate string
+	//VCS revision used on the links. If empty, the 'vcs.revision' of the build info is used
+	PermalinkRevision string
+	//Sets if the output includes the error fingerprint (see e2h.Fingerprint), useful to group the same logical errors
+	IncludeFingerprint bool
 }
 
 type Formatter interface {
@@ -7,19 +7,26 @@ import (
 	"encoding/json"
 	"fmt"
 
-	"github.com/cdleo/go-commons/formatter"
 	"github.com/cdleo/go-e2h"
 )
 
 type jsonStack struct {
-	FuncName string `json:"func"`
-	Caller   string `json:"caller"`
-	Context  string `json:"context,omitempty"`
+	FuncName string   `json:"func,omitempty"`
+	Caller   string   `json:"caller,omitempty"`
+	Context  string   `json:"context,omitempty"`
+	Source   []string `json:"source,omitempty"`
+	URL      string   `json:"url,omitempty"`
 }
 
 type jsonDetails struct {
-	Err   string      `json:"error"`
-	Stack []jsonStack `json:"stack_trace"`
+	Err         string            `json:"error"`
+	Code        string            `json:"code,omitempty"`
+	Template    string            `json:"template,omitempty"`
+	Fingerprint string            `json:"fingerprint,omitempty"`
+	Stack       []jsonStack       `json:"stack_trace"`
+	Fields      map[string]string `json:"fields,omitempty"`
+	SpawnedFrom []jsonStack       `json:"spawned_from,omitempty"`
+	CallStack   []jsonStack       `json:"call_stack,omitempty"`
 }
 
 type jsonSource struct {
@@ -27,15 +34,34 @@ type jsonSource struct {
 	Context string `json:"context,omitempty"`
 }
 
-func newJSONStack(item *e2h.StackDetails, hidingMethod formatter.HidingMethod, pathOrFolder string) jsonStack {
+func newJSONStack(item *e2h.StackDetails, params *Params) jsonStack {
 
-	filePath := formatter.FormatSourceFile(item.File, hidingMethod, pathOrFolder)
+	var caller string
+	if len(item.File) > 0 {
+		caller = fmt.Sprintf("%s:%d", formatSourceFile(item, params), item.Line)
+	}
 
 	return jsonStack{
 		FuncName: item.FuncName,
-		Caller:   fmt.Sprintf("%s:%d", filePath, item.Line),
-		Context:  item.Message,
+		Caller:   caller,
+		Context:  redact(item.Message, params.Redaction),
+		Source:   sourceSnippet(item.File, item.Line, params.SourceContextLines),
+		URL:      permalink(item, params),
+	}
+}
+
+// This function returns the frames of an additional section (i.e. the full call stack), or nil if there are no frames
+func newJSONSection(stack []e2h.StackDetails, params *Params) []jsonStack {
+
+	var section []jsonStack
+	for _, item := range stack {
+		section = append(section, jsonStack{
+			FuncName: item.FuncName,
+			Caller:   fmt.Sprintf("%s:%d", formatSourceFile(&item, params), item.Line),
+		})
 	}
+
+	return section
 }
 
 type jsonFormatter struct {
@@ -51,15 +77,15 @@ func (s *jsonFormatter) Source(err error) string {
 	var source jsonSource
 	switch err := err.(type) {
 	case e2h.EnhancedError:
-		source.Err = err.Cause().Error()
+		source.Err = redact(err.Cause().Error(), nil)
 		if len(err.Stack()) > 0 {
 			stack := err.Stack()
 			if len(stack[0].Message) > 0 {
-				source.Context = stack[0].Message
+				source.Context = redact(stack[0].Message, nil)
 			}
 		}
 	default:
-		source.Err = err.Error()
+		source.Err = redact(err.Error(), nil)
 	}
 
 	if result, marshalError := json.Marshal(source); marshalError != nil {
@@ -72,38 +98,53 @@ func (s *jsonFormatter) Source(err error) string {
 
 // This function returns the error stack information in a JSON format
 func (s *jsonFormatter) Format(err error, params Params) string {
+	return marshalJSON(newJSONDetails(err, &params), params.Beautify)
+}
+
+func newJSONDetails(err error, params *Params) jsonDetails {
 
 	details := jsonDetails{
-		Err:   err.Error(),
+		Err:   redact(err.Error(), params.Redaction),
 		Stack: make([]jsonStack, 0),
 	}
+	if params.IncludeFingerprint {
+		details.Fingerprint = e2h.Fingerprint(err)
+	}
 
 	switch err := err.(type) {
 	case e2h.EnhancedError:
-		details.Err = err.Cause().Error()
+		details.Err = redact(err.Cause().Error(), params.Redaction)
+		details.Code, details.Template, _ = definition(err)
 		stackDetails := err.Stack()
 		if params.InvertCallstack {
 			for i := len(stackDetails) - 1; i >= 0; i-- {
-				details.Stack = append(details.Stack, newJSONStack(&stackDetails[i],
-					params.PathHidingMethod, params.PathHidingValue))
+				details.Stack = append(details.Stack, newJSONStack(&stackDetails[i], params))
 			}
 		} else {
 			for i := 0; i <= len(stackDetails)-1; i++ {
-				details.Stack = append(details.Stack, newJSONStack(&stackDetails[i],
-					params.PathHidingMethod, params.PathHidingValue))
+				details.Stack = append(details.Stack, newJSONStack(&stackDetails[i], params))
 			}
 		}
+		details.Fields = errorFields(err, params)
+		details.SpawnedFrom = newJSONSection(spawnedFrom(err, params), params)
+		details.CallStack = newJSONSection(callStack(err, params), params)
 
 	default:
 		//Do Nothing
 	}
 
+	return details
+}
+
+// This function returns the value encoded as JSON, or an empty string on error
+func marshalJSON(value interface{}, beautify bool) string {
+
 	var result []byte
 	var marshalError error
-	if params.Beautify {
-		result, marshalError = json.MarshalIndent(details, "", "\t")
+	if beautify {
+		result, marshalError = json.MarshalIndent(value, "", "\t")
 	} else {
-		result, marshalError = json.Marshal(details)
+		result, marshalError = json.Marshal(value)
 	}
 	if marshalError != nil {
 		return ""
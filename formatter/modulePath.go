@@ -0,0 +1,232 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"math"
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+
+	"github.com/cdleo/go-commons/formatter"
+	"github.com/cdleo/go-e2h"
+)
+
+// Path hiding method that renders the files relative to its Go module (module/path/file.go),
+// the GOROOT ones as $GOROOT/src/pkg/file.go and the module cache ones as module@version/pkg/file.go.
+// It doesn't require any 'PathHidingValue', and works the same on -trimpath builds.
+// The go-commons methods are numbered from zero, so the highest value is used to not collide with the ones added there
+const HidingMethod_Module formatter.HidingMethod = math.MaxInt8
+
+const (
+	goRootPrefix   = "$GOROOT/src/"
+	moduleCacheDir = "/pkg/mod/"
+)
+
+// Main module details, taken from the build info
+var mainModule struct {
+	sync.Once
+	path     string
+	revision string
+}
+
+// This function loads (only once) the main module details from the build info
+func loadMainModule() {
+	mainModule.Do(func() {
+		if info, ok := debug.ReadBuildInfo(); ok {
+			mainModule.path = info.Main.Path
+			for _, setting := range info.Settings {
+				if setting.Key == "vcs.revision" {
+					mainModule.revision = setting.Value
+				}
+			}
+		}
+	})
+}
+
+// This function returns the path of the main module, according to the build info
+func mainModulePath() string {
+	loadMainModule()
+	return mainModule.path
+}
+
+// This function returns the VCS revision of the main module, according to the build info
+func mainModuleRevision() string {
+	loadMainModule()
+	return mainModule.revision
+}
+
+// Cache of the directories already looked up for a go.mod file, with the module path found (or empty)
+var moduleRoots = struct {
+	sync.Mutex
+	dirs map[string]moduleRoot
+}{dirs: make(map[string]moduleRoot)}
+
+type moduleRoot struct {
+	dir  string
+	path string
+}
+
+// This function format the sourcefile of the frame according to the provided params
+func formatSourceFile(item *e2h.StackDetails, params *Params) string {
+	if params.PathHidingMethod == HidingMethod_Module {
+		return moduleSourceFile(item.File, item.FuncName)
+	}
+	return formatter.FormatSourceFile(item.File, params.PathHidingMethod, params.PathHidingValue)
+}
+
+// This function returns the file path relative to its module (or GOROOT)
+func moduleSourceFile(file string, funcName string) string {
+
+	if len(file) == 0 {
+		return file
+	}
+
+	// -trimpath builds already have the module (or std package) relative paths
+	if !filepath.IsAbs(file) {
+		file = filepath.ToSlash(file)
+		if strings.HasPrefix(file, "$GOROOT/") || !isStdPackage(path.Dir(file)) {
+			return file
+		}
+		return goRootPrefix + file
+	}
+
+	file = filepath.ToSlash(file)
+	if i := strings.Index(file, moduleCacheDir); i >= 0 {
+		modFile := file[i+len(moduleCacheDir):]
+		if at := strings.Index(modFile, "@"); at > 0 && !strings.Contains(modFile[:at], "@") {
+			return unescapeModulePath(modFile)
+		}
+	}
+
+	base := path.Base(file)
+	pkg := packagePath(funcName)
+	switch {
+	case len(pkg) == 0:
+		return file
+	case pkg == "main":
+		if root, found := lookupModuleRoot(path.Dir(file)); found {
+			return root.path + strings.TrimPrefix(file, root.dir)
+		}
+		if modPath := mainModulePath(); len(modPath) > 0 {
+			return modPath + "/" + base
+		}
+		return file
+	case isStdPackage(pkg) && strings.HasSuffix(path.Dir(file), "/src/"+pkg):
+		return goRootPrefix + pkg + "/" + base
+	default:
+		return pkg + "/" + base
+	}
+}
+
+// This function returns the import path of the package of the function
+// (i.e. "github.com/cdleo/go-e2h" from "github.com/cdleo/go-e2h.(*enhancedError).Error").
+// The dots of the last path element are escaped on the runtime symbols (i.e. "gopkg.in/yaml%2ev3.(*parser).parse"),
+// but not on the unescaped ones (i.e. "gopkg.in/yaml.v3.(*parser).parse"), where a major version suffix is skipped
+func packagePath(funcName string) string {
+
+	lastSlash := strings.LastIndex(funcName, "/")
+	elem := funcName[lastSlash+1:]
+	dot := strings.Index(elem, ".")
+	if dot < 0 {
+		return ""
+	}
+	if next := strings.Index(elem[dot+1:], "."); next > 0 && isMajorVersion(elem[dot+1:dot+1+next]) {
+		dot += 1 + next
+	}
+
+	pkg := strings.ReplaceAll(funcName[:lastSlash+1+dot], "%2e", ".")
+
+	return strings.TrimSuffix(pkg, "_test")
+}
+
+// This function returns true if the value is a major version suffix (i.e. "v3" from "gopkg.in/yaml.v3")
+func isMajorVersion(value string) bool {
+
+	if len(value) < 2 || value[0] != 'v' {
+		return false
+	}
+	for _, c := range value[1:] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// This function returns true if the package belongs to the standard library
+func isStdPackage(pkg string) bool {
+
+	if modPath := mainModulePath(); len(modPath) > 0 && (pkg == modPath || strings.HasPrefix(pkg, modPath+"/")) {
+		return false
+	}
+
+	firstElem := pkg
+	if i := strings.Index(pkg, "/"); i >= 0 {
+		firstElem = pkg[:i]
+	}
+
+	return len(firstElem) > 0 && firstElem != "." && !strings.Contains(firstElem, ".")
+}
+
+// This function walks up from the directory looking for a go.mod file
+func lookupModuleRoot(dir string) (moduleRoot, bool) {
+
+	moduleRoots.Lock()
+	defer moduleRoots.Unlock()
+
+	root, found := moduleRoots.dirs[dir]
+	if !found {
+		for current := dir; ; current = path.Dir(current) {
+			if lines := readSourceLines(filepath.FromSlash(path.Join(current, "go.mod"))); lines != nil {
+				root = moduleRoot{dir: current, path: moduleDirective(lines)}
+				break
+			}
+			if parent := path.Dir(current); parent == current {
+				break
+			}
+		}
+		moduleRoots.dirs[dir] = root
+	}
+
+	return root, len(root.path) > 0
+}
+
+// This function returns the module path declared on the lines of a go.mod file
+func moduleDirective(lines []string) string {
+	for _, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], "\"`")
+		}
+	}
+	return ""
+}
+
+// This function reverts the case-encoding of the module cache paths (i.e. "!burnt!sushi" to "BurntSushi")
+func unescapeModulePath(modPath string) string {
+
+	if !strings.Contains(modPath, "!") {
+		return modPath
+	}
+
+	var result strings.Builder
+	upper := false
+	for _, r := range modPath {
+		switch {
+		case r == '!':
+			upper = true
+		case upper:
+			result.WriteString(strings.ToUpper(string(r)))
+			upper = false
+		default:
+			result.WriteRune(r)
+		}
+	}
+
+	return result.String()
+}
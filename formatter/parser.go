@@ -0,0 +1,140 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cdleo/go-e2h"
+)
+
+var (
+	rawFrameRegexp       = regexp.MustCompile(`^(\S+) \((.*):(\d+)\)(?: \S+)?$`)
+	rawFingerprintRegexp = regexp.MustCompile(` \(fingerprint: [0-9a-f]*\)$`)
+)
+
+const rawUnknownFrame = "<unknown>"
+
+type jsonParsedDetails struct {
+	Err    *string           `json:"error"`
+	Stack  *[]jsonStack      `json:"stack_trace"`
+	Fields map[string]string `json:"fields"`
+}
+
+// This function rebuilds the enhanced error from the output of the JSON formatter. The 'InvertCallstack'
+// param must be the same used to format it. The frames keep the file paths as they were rendered
+func ParseJSON(data string, params Params) (e2h.EnhancedError, error) {
+
+	var details jsonParsedDetails
+	if err := json.Unmarshal([]byte(data), &details); err != nil {
+		return nil, e2h.Tracem(err, "invalid JSON trace")
+	}
+	if details.Err == nil || details.Stack == nil {
+		return nil, e2h.Trace(errors.New("invalid JSON trace: 'error' and 'stack_trace' fields are required"))
+	}
+
+	stack := make([]e2h.StackDetails, 0, len(*details.Stack))
+	for _, item := range *details.Stack {
+		file, line := splitCaller(item.Caller)
+		stack = append(stack, e2h.StackDetails{
+			File:     file,
+			Line:     line,
+			FuncName: item.FuncName,
+			Message:  item.Context,
+		})
+	}
+
+	enhancedErr := e2h.Rebuild(errors.New(*details.Err), sortFrames(stack, &params))
+
+	return e2h.AttachFields(enhancedErr, details.Fields).(e2h.EnhancedError), nil
+}
+
+// This function rebuilds the enhanced error from the beautified output of the raw formatter. The 'InvertCallstack'
+// param must be the same used to format it. The frames keep the file paths as they were rendered
+func ParseRaw(data string, params Params) (e2h.EnhancedError, error) {
+
+	if !params.Beautify {
+		return nil, e2h.Trace(errors.New("only the beautified raw format could be parsed"))
+	}
+
+	lines := strings.Split(strings.TrimRight(data, "\n"), "\n")
+
+	var cause string
+	if !params.InvertCallstack {
+		cause, lines = lines[0], lines[1:]
+	}
+
+	stack := make([]e2h.StackDetails, 0)
+	for _, line := range lines {
+		if strings.HasPrefix(line, "\t\t") {
+			// Source snippet
+			continue
+		}
+		if strings.HasPrefix(line, "\t") {
+			if len(stack) == 0 {
+				return nil, e2h.Trace(fmt.Errorf("invalid raw trace: context without frame [%s]", line))
+			}
+			stack[len(stack)-1].Message = strings.TrimPrefix(line, "\t")
+			continue
+		}
+		if !isRawFrame(line) {
+			if params.InvertCallstack && len(cause) == 0 {
+				cause = line
+				continue
+			}
+			// Additional sections (i.e. "Call stack:") are ignored
+			break
+		}
+		if params.InvertCallstack && len(cause) > 0 {
+			break
+		}
+		stack = append(stack, parseRawFrame(line))
+	}
+
+	if len(cause) == 0 {
+		return nil, e2h.Trace(errors.New("invalid raw trace: the cause is missing"))
+	}
+	cause = rawFingerprintRegexp.ReplaceAllString(cause, "")
+
+	return e2h.Rebuild(errors.New(cause), sortFrames(stack, &params)), nil
+}
+
+func isRawFrame(line string) bool {
+	return line == rawUnknownFrame || rawFrameRegexp.MatchString(line)
+}
+
+func parseRawFrame(line string) e2h.StackDetails {
+
+	matches := rawFrameRegexp.FindStringSubmatch(line)
+	if matches == nil {
+		return e2h.StackDetails{}
+	}
+	lineNumber, _ := strconv.Atoi(matches[3])
+
+	return e2h.StackDetails{
+		File:     matches[2],
+		Line:     lineNumber,
+		FuncName: matches[1],
+	}
+}
+
+// This function splits a "file:line" caller
+func splitCaller(caller string) (string, int) {
+
+	separator := strings.LastIndex(caller, ":")
+	if separator < 0 {
+		return caller, 0
+	}
+	line, err := strconv.Atoi(caller[separator+1:])
+	if err != nil {
+		return caller, 0
+	}
+
+	return caller[:separator], line
+}
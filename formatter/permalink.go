@@ -0,0 +1,62 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/cdleo/go-e2h"
+)
+
+// Placeholders allowed on the permalink templates
+const (
+	Permalink_Revision = "{revision}"
+	Permalink_Path     = "{path}"
+	Permalink_Line     = "{line}"
+)
+
+// This function returns the permalink template of a GitHub repository (i.e. "https://github.com/cdleo/go-e2h")
+func GitHubPermalink(repoURL string) string {
+	return strings.TrimSuffix(repoURL, "/") + "/blob/" + Permalink_Revision + "/" + Permalink_Path + "#L" + Permalink_Line
+}
+
+// This function returns the permalink template of a GitLab repository
+func GitLabPermalink(repoURL string) string {
+	return strings.TrimSuffix(repoURL, "/") + "/-/blob/" + Permalink_Revision + "/" + Permalink_Path + "#L" + Permalink_Line
+}
+
+// This function returns the permalink template of a Gitea repository
+func GiteaPermalink(repoURL string) string {
+	return strings.TrimSuffix(repoURL, "/") + "/src/commit/" + Permalink_Revision + "/" + Permalink_Path + "#L" + Permalink_Line
+}
+
+// This function returns the link to the source line of the frame, or an empty string if the
+// permalinks are disabled, the revision is unknown or the frame doesn't belong to the main module
+func permalink(item *e2h.StackDetails, params *Params) string {
+
+	if len(params.PermalinkTemplate) == 0 {
+		return ""
+	}
+
+	revision := params.PermalinkRevision
+	if len(revision) == 0 {
+		revision = mainModuleRevision()
+	}
+	modPath := mainModulePath()
+	if len(revision) == 0 || len(modPath) == 0 {
+		return ""
+	}
+
+	file := moduleSourceFile(item.File, item.FuncName)
+	if !strings.HasPrefix(file, modPath+"/") {
+		return ""
+	}
+
+	return strings.NewReplacer(
+		Permalink_Revision, revision,
+		Permalink_Path, strings.TrimPrefix(file, modPath+"/"),
+		Permalink_Line, strconv.Itoa(item.Line),
+	).Replace(params.PermalinkTemplate)
+}
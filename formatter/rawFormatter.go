@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"strings"
 
-	"github.com/cdleo/go-commons/formatter"
 	"github.com/cdleo/go-e2h"
 )
 
@@ -22,16 +21,16 @@ func newRawFormatter() Formatter {
 func (s *rawFormatter) Source(err error) string {
 	switch err := err.(type) {
 	case e2h.EnhancedError:
-		sourceError := err.Cause().Error()
+		sourceError := redact(err.Cause().Error(), nil)
 		if len(err.Stack()) > 0 {
 			stack := err.Stack()
 			if len(stack[0].Message) > 0 {
-				sourceError = fmt.Sprintf("%s [%s]", sourceError, stack[0].Message)
+				sourceError = fmt.Sprintf("%s [%s]", sourceError, redact(stack[0].Message, nil))
 			}
 		}
 		return sourceError
 	default:
-		return err.Error()
+		return redact(err.Error(), nil)
 	}
 }
 
@@ -42,43 +41,132 @@ func (s *rawFormatter) Format(err error, params Params) string {
 	var causeFormat, withInfoTrace, withoutInfoTrace string
 	if params.Beautify {
 		causeFormat = "%s\n"
-		withInfoTrace = "%s (%s:%d)\n\t%s\n"
-		withoutInfoTrace = "%s (%s:%d)\n"
+		withInfoTrace = "%s\n\t%s\n"
+		withoutInfoTrace = "%s\n"
 	} else {
 		causeFormat = "%s; "
-		withInfoTrace = "%s (%s:%d) [%s]; "
-		withoutInfoTrace = "%s (%s:%d); "
+		withInfoTrace = "%s [%s]; "
+		withoutInfoTrace = "%s; "
 	}
 
 	switch err := err.(type) {
 	case e2h.EnhancedError:
+		cause := s.formatCause(err.Cause().Error(), err, params)
 		stackDetails := err.Stack()
 		if params.InvertCallstack {
 			for i := len(stackDetails) - 1; i >= 0; i-- {
 				result += s.formatItem(withInfoTrace, withoutInfoTrace, params, stackDetails[i])
 			}
-			result += fmt.Sprintf(causeFormat, err.Cause().Error())
+			result += fmt.Sprintf(causeFormat, cause)
 		} else {
-			result = fmt.Sprintf(causeFormat, err.Cause().Error())
+			result = fmt.Sprintf(causeFormat, cause)
 			for i := 0; i <= len(stackDetails)-1; i++ {
 				stackItem := stackDetails[i]
 				result += s.formatItem(withInfoTrace, withoutInfoTrace, params, stackItem)
 			}
 		}
+		result += s.formatDefinition(err, params)
+		result += s.formatFields(errorFields(err, &params), params)
+		result += s.formatSection("Spawned from", spawnedFrom(err, &params), params)
+		result += s.formatSection("Call stack", callStack(err, &params), params)
 	default:
-		result = err.Error()
+		result = s.formatCause(err.Error(), err, params)
 	}
 
 	return strings.TrimSpace(result)
 }
 
+func (s *rawFormatter) formatCause(cause string, err error, params Params) string {
+
+	cause = redact(cause, params.Redaction)
+	if params.IncludeFingerprint {
+		return fmt.Sprintf("%s (fingerprint: %s)", cause, e2h.Fingerprint(err))
+	}
+
+	return cause
+}
+
 func (s *rawFormatter) formatItem(withInfoTrace string, withoutInfoTrace string, params Params, item e2h.StackDetails) string {
 
-	filePath := formatter.FormatSourceFile(item.File, params.PathHidingMethod, params.PathHidingValue)
+	frame := s.formatFrame(item, params)
 
+	var result string
 	if len(item.Message) > 0 {
-		return fmt.Sprintf(withInfoTrace, item.FuncName, filePath, item.Line, item.Message)
+		result = fmt.Sprintf(withInfoTrace, frame, redact(item.Message, params.Redaction))
 	} else {
-		return fmt.Sprintf(withoutInfoTrace, item.FuncName, filePath, item.Line)
+		result = fmt.Sprintf(withoutInfoTrace, frame)
+	}
+
+	if params.Beautify {
+		for _, line := range sourceSnippet(item.File, item.Line, params.SourceContextLines) {
+			result += fmt.Sprintf("\t\t%s\n", line)
+		}
+	}
+
+	return result
+}
+
+func (s *rawFormatter) formatFrame(item e2h.StackDetails, params Params) string {
+
+	if len(item.FuncName) == 0 && len(item.File) == 0 {
+		return "<unknown>"
+	}
+
+	filePath := formatSourceFile(&item, &params)
+	frame := fmt.Sprintf("%s (%s:%d)", item.FuncName, filePath, item.Line)
+	if url := permalink(&item, &params); len(url) > 0 {
+		frame = fmt.Sprintf("%s %s", frame, url)
+	}
+
+	return frame
+}
+
+// This function returns an additional section of frames (i.e. the full call stack), or an empty string if there are no frames
+func (s *rawFormatter) formatSection(title string, stack []e2h.StackDetails, params Params) string {
+
+	if len(stack) == 0 {
+		return ""
+	}
+
+	frames := make([]string, 0, len(stack))
+	for _, item := range stack {
+		frames = append(frames, s.formatFrame(item, params))
+	}
+
+	if params.Beautify {
+		return fmt.Sprintf("%s:\n\t%s\n", title, strings.Join(frames, "\n\t"))
+	}
+	return fmt.Sprintf("%s: %s; ", strings.ToLower(title), strings.Join(frames, ", "))
+}
+
+// This function returns the section with the code and template of the cause definition, or an empty string if it's not defined
+func (s *rawFormatter) formatDefinition(err e2h.EnhancedError, params Params) string {
+
+	code, template, ok := definition(err)
+	if !ok {
+		return ""
+	}
+
+	if params.Beautify {
+		return fmt.Sprintf("Definition:\n\tcode=%s\n\ttemplate=%s\n", code, template)
+	}
+	return fmt.Sprintf("definition: code=%s, template=%s; ", code, template)
+}
+
+// This function returns the section with the request-scoped metadata, or an empty string if there are no fields
+func (s *rawFormatter) formatFields(fields map[string]string, params Params) string {
+
+	if len(fields) == 0 {
+		return ""
+	}
+
+	items := make([]string, 0, len(fields))
+	for _, name := range sortedFields(fields) {
+		items = append(items, fmt.Sprintf("%s=%s", name, fields[name]))
+	}
+
+	if params.Beautify {
+		return fmt.Sprintf("Fields:\n\t%s\n", strings.Join(items, "\n\t"))
 	}
+	return fmt.Sprintf("fields: %s; ", strings.Join(items, ", "))
 }
@@ -0,0 +1,150 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Detector is a bitmask used to select the built-in redaction detectors
+type Detector uint8
+
+// Allowed built-in detectors.
+const (
+	Detector_BearerToken Detector = 1 << iota
+	Detector_URLPassword
+	Detector_Email
+	Detector_CreditCard
+
+	Detector_None Detector = 0
+	Detector_All           = Detector_BearerToken | Detector_URLPassword | Detector_Email | Detector_CreditCard
+)
+
+// Default text used to replace the sensitive values
+const DefaultRedactionMask = "[REDACTED]"
+
+var (
+	bearerTokenRegexp = regexp.MustCompile(`(?i)(\bbearer\s+)[A-Za-z0-9\-._~+/]+=*`)
+	urlPasswordRegexp = regexp.MustCompile(`(?i)(\b[a-z][a-z0-9+.\-]*://[^\s:/?#@]+:)[^\s/?#@]+(@)`)
+	emailRegexp       = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9\-]+(\.[A-Za-z0-9\-]+)*\.[A-Za-z]{2,}`)
+	creditCardRegexp  = regexp.MustCompile(`\b(?:\d[ \-]?){12,18}\d\b`)
+)
+
+// User-supplied redaction rule
+type RedactionRule struct {
+	//Expression to look for into the messages
+	Pattern *regexp.Regexp
+	//Text to use instead of each match, supporting the regexp expansion syntax ($1, ${name}).
+	//If empty, the policy 'Mask' is used
+	Replacement string
+}
+
+// Entity that defines which sensitive values are removed from the formatted messages
+type RedactionPolicy struct {
+	//Built-in detectors to apply
+	Detectors Detector
+	//User-supplied rules, applied after the built-in detectors
+	Rules []RedactionRule
+	//Text to use instead of the sensitive values. If empty, 'DefaultRedactionMask' is used
+	Mask string
+}
+
+var (
+	globalPolicyMutex sync.RWMutex
+	globalPolicy      *RedactionPolicy
+)
+
+// This function sets the policy used when the Params doesn't provide one, and by the Source function.
+// A nil value disables the global redaction
+func SetRedactionPolicy(policy *RedactionPolicy) {
+	globalPolicyMutex.Lock()
+	defer globalPolicyMutex.Unlock()
+	globalPolicy = policy
+}
+
+// This function returns the current global redaction policy (if exists)
+func GetRedactionPolicy() *RedactionPolicy {
+	globalPolicyMutex.RLock()
+	defer globalPolicyMutex.RUnlock()
+	return globalPolicy
+}
+
+// This function returns the text with all the sensitive values replaced
+func (p *RedactionPolicy) Redact(text string) string {
+
+	if p == nil || len(text) == 0 {
+		return text
+	}
+
+	mask := p.Mask
+	if len(mask) == 0 {
+		mask = DefaultRedactionMask
+	}
+	literalMask := strings.ReplaceAll(mask, "$", "$$")
+
+	if p.Detectors&Detector_BearerToken != 0 {
+		text = bearerTokenRegexp.ReplaceAllString(text, "${1}"+literalMask)
+	}
+	if p.Detectors&Detector_URLPassword != 0 {
+		text = urlPasswordRegexp.ReplaceAllString(text, "${1}"+literalMask+"${2}")
+	}
+	if p.Detectors&Detector_Email != 0 {
+		text = emailRegexp.ReplaceAllLiteralString(text, mask)
+	}
+	if p.Detectors&Detector_CreditCard != 0 {
+		text = creditCardRegexp.ReplaceAllStringFunc(text, func(match string) string {
+			if isLuhnValid(match) {
+				return mask
+			}
+			return match
+		})
+	}
+
+	for _, rule := range p.Rules {
+		if rule.Pattern == nil {
+			continue
+		}
+		if len(rule.Replacement) > 0 {
+			text = rule.Pattern.ReplaceAllString(text, rule.Replacement)
+		} else {
+			text = rule.Pattern.ReplaceAllLiteralString(text, mask)
+		}
+	}
+
+	return text
+}
+
+// Utility function that applies the provided policy or, if nil, the global one
+func redact(text string, policy *RedactionPolicy) string {
+	if policy == nil {
+		policy = GetRedactionPolicy()
+	}
+	return policy.Redact(text)
+}
+
+// Utility function that validates the checksum of a card number, ignoring separators
+func isLuhnValid(number string) bool {
+
+	sum := 0
+	digits := 0
+	for i := len(number) - 1; i >= 0; i-- {
+		c := number[i]
+		if c < '0' || c > '9' {
+			continue
+		}
+		d := int(c - '0')
+		if digits%2 == 1 {
+			d *= 2
+			if d > 9 {
+				d -= 9
+			}
+		}
+		sum += d
+		digits++
+	}
+
+	return digits >= 13 && sum%10 == 0
+}
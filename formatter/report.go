@@ -0,0 +1,64 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/cdleo/go-e2h"
+)
+
+type jsonReportEntry struct {
+	Rank        int         `json:"rank"`
+	Fingerprint string      `json:"fingerprint"`
+	Count       uint64      `json:"count"`
+	FirstSeen   time.Time   `json:"first_seen"`
+	LastSeen    time.Time   `json:"last_seen"`
+	Details     jsonDetails `json:"details"`
+}
+
+// This function returns the statistics of the collected errors (i.e. from e2h.Collector.Top)
+// in the requested format, rendering the representative error of each group (as it was when collected) with the provided params
+func FormatReport(stats []e2h.ErrorStats, format Format, params Params) (string, error) {
+
+	switch format {
+	case Format_Raw:
+		return formatRawReport(stats, params), nil
+	case Format_JSON:
+		entries := make([]jsonReportEntry, 0, len(stats))
+		for i, item := range stats {
+			entries = append(entries, jsonReportEntry{
+				Rank:        i + 1,
+				Fingerprint: item.Fingerprint,
+				Count:       item.Count,
+				FirstSeen:   item.FirstSeen,
+				LastSeen:    item.LastSeen,
+				Details:     newJSONDetails(item.Sample, &params),
+			})
+		}
+		return marshalJSON(entries, params.Beautify), nil
+	default:
+		return "", fmt.Errorf("unknown format [%d]", format)
+	}
+}
+
+func formatRawReport(stats []e2h.ErrorStats, params Params) string {
+
+	rawFormatter := newRawFormatter()
+
+	var result strings.Builder
+	for i, item := range stats {
+		header := fmt.Sprintf("#%d - %d occurrence(s) (fingerprint: %s, first seen: %s, last seen: %s)",
+			i+1, item.Count, item.Fingerprint, item.FirstSeen.Format(time.RFC3339), item.LastSeen.Format(time.RFC3339))
+		if params.Beautify {
+			fmt.Fprintf(&result, "%s\n%s\n\n", header, rawFormatter.Format(item.Sample, params))
+		} else {
+			fmt.Fprintf(&result, "%s: %s\n", header, rawFormatter.Format(item.Sample, params))
+		}
+	}
+
+	return strings.TrimSpace(result.String())
+}
@@ -0,0 +1,81 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Cache of the source files already read. A nil entry means the file is not available
+var sourceCache = struct {
+	sync.Mutex
+	files map[string][]string
+}{files: make(map[string][]string)}
+
+// This function returns the lines of the source file (from cache, if was already read)
+// or nil, if the file is not available
+func readSourceLines(file string) []string {
+
+	sourceCache.Lock()
+	defer sourceCache.Unlock()
+
+	if lines, found := sourceCache.files[file]; found {
+		return lines
+	}
+
+	var lines []string
+	if f, err := os.Open(file); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			lines = append(lines, strings.TrimRight(scanner.Text(), " \t\r"))
+		}
+		if scanner.Err() != nil {
+			lines = nil
+		}
+		f.Close()
+	}
+	sourceCache.files[file] = lines
+
+	return lines
+}
+
+// This function returns the lines of source code around the given line, with the line itself marked,
+// or nil if the file (or the line) is not available
+func sourceSnippet(file string, line int, contextLines int) []string {
+
+	if contextLines <= 0 || len(file) == 0 || line <= 0 {
+		return nil
+	}
+
+	lines := readSourceLines(file)
+	if line > len(lines) {
+		return nil
+	}
+
+	first := line - contextLines
+	if first < 1 {
+		first = 1
+	}
+	last := line + contextLines
+	if last > len(lines) {
+		last = len(lines)
+	}
+
+	width := len(strconv.Itoa(last))
+	snippet := make([]string, 0, last-first+1)
+	for i := first; i <= last; i++ {
+		marker := " "
+		if i == line {
+			marker = ">"
+		}
+		snippet = append(snippet, strings.TrimRight(fmt.Sprintf("%s %*d | %s", marker, width, i, lines[i-1]), " "))
+	}
+
+	return snippet
+}
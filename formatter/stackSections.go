@@ -0,0 +1,87 @@
+/*
+Package e2hformat is the formatter's package of the Enhanced Error Handling module
+*/
+package e2hformat
+
+import (
+	"sort"
+
+	"github.com/cdleo/go-e2h"
+)
+
+// This function returns the full call stack of the error (if it was captured), sorted according to the params
+func callStack(err e2h.EnhancedError, params *Params) []e2h.StackDetails {
+
+	stacker, ok := err.(e2h.CallStacker)
+	if !ok {
+		return nil
+	}
+
+	return sortFrames(stacker.CallStack(), params)
+}
+
+// This function returns the call stack of the goroutine that spawned the worker which returned the error (if it
+// was captured), sorted according to the params
+func spawnedFrom(err e2h.EnhancedError, params *Params) []e2h.StackDetails {
+
+	stacker, ok := err.(e2h.SpawnStacker)
+	if !ok {
+		return nil
+	}
+
+	return sortFrames(stacker.SpawnedFrom(), params)
+}
+
+// This function returns the frames (sorted from the deepest) according to the 'InvertCallstack' param
+func sortFrames(stack []e2h.StackDetails, params *Params) []e2h.StackDetails {
+
+	if !params.InvertCallstack || len(stack) == 0 {
+		return stack
+	}
+
+	result := make([]e2h.StackDetails, 0, len(stack))
+	for i := len(stack) - 1; i >= 0; i-- {
+		result = append(result, stack[i])
+	}
+
+	return result
+}
+
+// This function returns the code and message template of the error cause, if it's an instance of a definition (see e2h.Define)
+func definition(err e2h.EnhancedError) (code string, template string, ok bool) {
+
+	definer, ok := err.Cause().(e2h.Definer)
+	if !ok {
+		return "", "", false
+	}
+
+	return definer.Code(), definer.Template(), true
+}
+
+// This function returns the request-scoped metadata of the error (if any), with its values redacted according to the params
+func errorFields(err e2h.EnhancedError, params *Params) map[string]string {
+
+	fielder, ok := err.(e2h.Fielder)
+	if !ok {
+		return nil
+	}
+
+	fields := fielder.Fields()
+	for field, value := range fields {
+		fields[field] = redact(value, params.Redaction)
+	}
+
+	return fields
+}
+
+// This function returns the field names, sorted
+func sortedFields(fields map[string]string) []string {
+
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
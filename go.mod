@@ -1,11 +1,12 @@
 module github.com/cdleo/go-e2h
 
-go 1.14
+go 1.18
 
 require github.com/stretchr/testify v1.7.1
 
 require (
 	github.com/cdleo/go-commons v0.0.0-20220328183115-77de79dd0070
 	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
 	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
 )
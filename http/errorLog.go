@@ -0,0 +1,236 @@
+/*
+Package e2hhttp is the net/http integration package of the Enhanced Error Handling module
+*/
+package e2hhttp
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+// Default amount of errors kept by an ErrorLog
+const DefaultErrorLogSize = 100
+
+// Entity with the details of a recorded error
+type ErrorLogEntry struct {
+	Time        time.Time
+	Fingerprint string
+	//Status code of the response (0 if unknown)
+	Code int
+	//Copy of the error, as it was when recorded
+	Err error
+}
+
+// Entity that keeps a ring buffer with the last recorded errors, and serves them (in the spirit
+// of net/http/pprof and expvar) as an HTML page or, with the 'format=json' query param, as a JSON API.
+// The entries could be filtered using the 'fingerprint', 'code' and 'since' (RFC3339 time or duration) query params
+type ErrorLog struct {
+	mutex     sync.RWMutex
+	entries   []ErrorLogEntry
+	next      int
+	full      bool
+	params    e2hformat.Params
+	formatter e2hformat.Formatter
+}
+
+type jsonErrorLogEntry struct {
+	Time        time.Time       `json:"time"`
+	Fingerprint string          `json:"fingerprint"`
+	Code        int             `json:"code,omitempty"`
+	Details     json.RawMessage `json:"details"`
+}
+
+// This function returns a new ErrorLog, that keeps the last 'size' errors (DefaultErrorLogSize if the
+// value is not positive) and formats them with the provided params
+func NewErrorLog(size int, params e2hformat.Params) *ErrorLog {
+
+	if size <= 0 {
+		size = DefaultErrorLogSize
+	}
+	jsonFormatter, _ := e2hformat.NewFormatter(e2hformat.Format_JSON)
+
+	return &ErrorLog{
+		entries:   make([]ErrorLogEntry, size),
+		params:    params,
+		formatter: jsonFormatter,
+	}
+}
+
+// This function adds the error to the log, replacing the oldest one if it's full. Nil errors are ignored
+func (l *ErrorLog) Record(err error, code int) {
+
+	if err == nil {
+		return
+	}
+
+	entry := ErrorLogEntry{
+		Time:        time.Now(),
+		Fingerprint: e2h.Fingerprint(err),
+		Code:        code,
+		Err:         snapshot(err),
+	}
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.entries[l.next] = entry
+	l.next = (l.next + 1) % len(l.entries)
+	if l.next == 0 {
+		l.full = true
+	}
+}
+
+// This function returns a copy of the enhanced error, with its cause, stack and fields, so the later
+// traces of the error don't change the recorded one (nor race with its formatting)
+func snapshot(err error) error {
+
+	enhancedErr, ok := err.(e2h.EnhancedError)
+	if !ok {
+		return err
+	}
+	copied := e2h.Rebuild(enhancedErr.Cause(), enhancedErr.Stack())
+	if fielder, ok := err.(e2h.Fielder); ok {
+		return e2h.AttachFields(copied, fielder.Fields())
+	}
+
+	return copied
+}
+
+// This function returns the recorded errors, newest first
+func (l *ErrorLog) Entries() []ErrorLogEntry {
+
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+
+	count := l.next
+	if l.full {
+		count = len(l.entries)
+	}
+
+	result := make([]ErrorLogEntry, 0, count)
+	for i := 1; i <= count; i++ {
+		result = append(result, l.entries[(l.next-i+len(l.entries))%len(l.entries)])
+	}
+
+	return result
+}
+
+func (l *ErrorLog) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+
+	entries, err := l.filter(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		result := make([]jsonErrorLogEntry, 0, len(entries))
+		for _, entry := range entries {
+			result = append(result, jsonErrorLogEntry{
+				Time:        entry.Time,
+				Fingerprint: entry.Fingerprint,
+				Code:        entry.Code,
+				Details:     json.RawMessage(l.formatter.Format(entry.Err, l.params)),
+			})
+		}
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		json.NewEncoder(w).Encode(result)
+		return
+	}
+
+	params := l.params
+	params.Beautify = true
+	page := htmlErrorLog{Entries: make([]htmlErrorLogEntry, 0, len(entries))}
+	for _, entry := range entries {
+		page.Entries = append(page.Entries, htmlErrorLogEntry{
+			ErrorLogEntry: entry,
+			Details:       l.formatter.Format(entry.Err, params),
+		})
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	errorLogTemplate.Execute(w, page)
+}
+
+// This function returns the entries that matches the filters of the request query
+func (l *ErrorLog) filter(r *http.Request) ([]ErrorLogEntry, error) {
+
+	query := r.URL.Query()
+	fingerprint := query.Get("fingerprint")
+
+	code := 0
+	if value := query.Get("code"); len(value) > 0 {
+		var err error
+		if code, err = strconv.Atoi(value); err != nil {
+			return nil, e2h.Tracef(err, "invalid code [%s]", value)
+		}
+	}
+
+	var since time.Time
+	if value := query.Get("since"); len(value) > 0 {
+		if duration, err := time.ParseDuration(value); err == nil {
+			since = time.Now().Add(-duration)
+		} else if since, err = time.Parse(time.RFC3339, value); err != nil {
+			return nil, e2h.Tracef(err, "invalid since [%s]", value)
+		}
+	}
+
+	entries := l.Entries()
+	result := make([]ErrorLogEntry, 0, len(entries))
+	for _, entry := range entries {
+		if len(fingerprint) > 0 && entry.Fingerprint != fingerprint {
+			continue
+		}
+		if code != 0 && entry.Code != code {
+			continue
+		}
+		if entry.Time.Before(since) {
+			continue
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+type htmlErrorLog struct {
+	Entries []htmlErrorLogEntry
+}
+
+type htmlErrorLogEntry struct {
+	ErrorLogEntry
+	Details string
+}
+
+var errorLogTemplate = template.Must(template.New("errorLog").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>Recent errors</title>
+<style>
+body { font-family: sans-serif; }
+table { border-collapse: collapse; width: 100%; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+pre { margin: 0; }
+</style>
+</head>
+<body>
+<h1>Recent errors</h1>
+<p>{{len .Entries}} error(s) - <a href="?">all</a> - <a href="?format=json">json</a></p>
+<table>
+<tr><th>Time</th><th>Code</th><th>Fingerprint</th><th>Details</th></tr>
+{{range .Entries}}<tr>
+<td>{{.Time.Format "2006-01-02T15:04:05.000Z07:00"}}</td>
+<td>{{if .Code}}<a href="?code={{.Code}}">{{.Code}}</a>{{end}}</td>
+<td><a href="?fingerprint={{.Fingerprint}}">{{.Fingerprint}}</a></td>
+<td><pre>{{.Details}}</pre></td>
+</tr>
+{{end}}</table>
+</body>
+</html>
+`))
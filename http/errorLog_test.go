@@ -0,0 +1,152 @@
+/*
+Package e2hhttp_test its the test package of the net/http integration of the Enhanced Error Handling module
+*/
+package e2hhttp_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2hhttp "github.com/cdleo/go-e2h/http"
+	"github.com/stretchr/testify/require"
+)
+
+type jsonLogEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Code        int    `json:"code"`
+	Details     struct {
+		Err string `json:"error"`
+	} `json:"details"`
+}
+
+func getJSONEntries(t *testing.T, errorLog *e2hhttp.ErrorLog, query string) []jsonLogEntry {
+	recorder := httptest.NewRecorder()
+	errorLog.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/errors?format=json&"+query, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+
+	var entries []jsonLogEntry
+	require.Nil(t, json.Unmarshal(recorder.Body.Bytes(), &entries))
+	return entries
+}
+
+func TestErrorLog_Entries_RingBuffer(t *testing.T) {
+
+	// Setup
+	errorLog := e2hhttp.NewErrorLog(3, e2hformat.Params{})
+
+	// Execute
+	for i := 1; i <= 5; i++ {
+		errorLog.Record(fmt.Errorf("error %d", i), 500)
+	}
+	errorLog.Record(nil, 500)
+	entries := errorLog.Entries()
+
+	// Check
+	require.Len(t, entries, 3)
+	require.Equal(t, "error 5", entries[0].Err.Error())
+	require.Equal(t, "error 4", entries[1].Err.Error())
+	require.Equal(t, "error 3", entries[2].Err.Error())
+}
+
+func TestErrorLog_Record_TracedAfterRecord(t *testing.T) {
+
+	// Setup
+	errorLog := e2hhttp.NewErrorLog(3, e2hformat.Params{})
+	err := e2h.Tracem(fmt.Errorf("user not found"), "loading user 10")
+	fields := e2h.AttachFields(err, map[string]string{e2h.Field_RequestID: "req-1"})
+
+	// Execute
+	errorLog.Record(fields, 404)
+	e2h.Tracem(err, "handling request")
+	entries := getJSONEntries(t, errorLog, "")
+
+	// Check
+	require.Len(t, entries, 1)
+	require.Equal(t, e2h.Fingerprint(e2h.Tracem(fmt.Errorf("user not found"), "loading user 10")), entries[0].Fingerprint)
+	stack := errorLog.Entries()[0].Err.(e2h.EnhancedError).Stack()
+	require.Len(t, stack, 1)
+	require.Equal(t, "loading user 10", stack[0].Message)
+	require.Equal(t, map[string]string{e2h.Field_RequestID: "req-1"}, errorLog.Entries()[0].Err.(e2h.Fielder).Fields())
+	require.Len(t, err.(e2h.EnhancedError).Stack(), 2)
+}
+
+func TestErrorLog_ServeHTTP_JSON_Filters(t *testing.T) {
+
+	// Setup
+	errorLog := e2hhttp.NewErrorLog(10, e2hformat.Params{})
+	notFound := e2h.Tracem(fmt.Errorf("user not found"), "loading user")
+	errorLog.Record(notFound, http.StatusNotFound)
+	errorLog.Record(e2h.Trace(fmt.Errorf("connection refused")), http.StatusInternalServerError)
+	errorLog.Record(e2h.Trace(fmt.Errorf("timeout")), http.StatusInternalServerError)
+
+	// Execute & Check
+	entries := getJSONEntries(t, errorLog, "")
+	require.Len(t, entries, 3)
+	require.Equal(t, "timeout", entries[0].Details.Err)
+
+	entries = getJSONEntries(t, errorLog, "code=404")
+	require.Len(t, entries, 1)
+	require.Equal(t, "user not found", entries[0].Details.Err)
+	require.Equal(t, e2h.Fingerprint(notFound), entries[0].Fingerprint)
+
+	entries = getJSONEntries(t, errorLog, "fingerprint="+e2h.Fingerprint(notFound))
+	require.Len(t, entries, 1)
+
+	require.Len(t, getJSONEntries(t, errorLog, "since=1h"), 3)
+	require.Len(t, getJSONEntries(t, errorLog, "since=2999-01-01T00:00:00Z"), 0)
+}
+
+func TestErrorLog_ServeHTTP_HTML(t *testing.T) {
+
+	// Setup
+	errorLog := e2hhttp.NewErrorLog(10, e2hformat.Params{})
+	errorLog.Record(e2h.Trace(fmt.Errorf("<script>alert(1)</script>")), http.StatusInternalServerError)
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	errorLog.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/errors", nil))
+
+	// Check
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.Contains(t, recorder.Body.String(), "1 error(s)")
+	require.Contains(t, recorder.Body.String(), "<a href=\"?code=500\">500</a>")
+	require.NotContains(t, recorder.Body.String(), "<script>")
+}
+
+func TestErrorLog_ServeHTTP_InvalidFilter(t *testing.T) {
+
+	// Setup
+	errorLog := e2hhttp.NewErrorLog(10, e2hformat.Params{})
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	errorLog.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/debug/errors?code=abc", nil))
+
+	// Check
+	require.Equal(t, http.StatusBadRequest, recorder.Code)
+}
+
+func TestMiddleware_HandleFunc_RecordsOnErrorLog(t *testing.T) {
+
+	// Setup
+	errorLog := e2hhttp.NewErrorLog(10, e2hformat.Params{})
+	middleware, _ := newTestMiddleware(t, e2hhttp.Options{ErrorLog: errorLog})
+	handler := middleware.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return e2h.Trace(fmt.Errorf("connection refused"))
+	})
+
+	// Execute
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Check
+	entries := errorLog.Entries()
+	require.Len(t, entries, 1)
+	require.Equal(t, http.StatusInternalServerError, entries[0].Code)
+}
@@ -0,0 +1,194 @@
+/*
+Package e2hhttp is the net/http integration package of the Enhanced Error Handling module
+*/
+package e2hhttp
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+// Handler function that returns an error, instead of writing it
+type ErrorHandlerFunc func(w http.ResponseWriter, r *http.Request) error
+
+// This function serves the request with a Middleware created with the default Options
+func (f ErrorHandlerFunc) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	defaultMiddleware.HandleFunc(f).ServeHTTP(w, r)
+}
+
+type Options struct {
+	//Format used to log the full trace
+	LogFormat e2hformat.Format
+	//Params used to log the full trace
+	LogParams e2hformat.Params
+	//Function used to log the full trace. If nil, the standard logger is used
+	Logger func(r *http.Request, status int, trace string)
+	//Function that returns the response status code of the error. If nil, or the code is not valid (out of 100-999), 500 is used
+	StatusMapper func(err error) int
+	//Format of the response body
+	ResponseFormat e2hformat.Format
+	//Sets if the response body shows the source of the error (see Formatter.Source), instead of the status text
+	ExposeSource bool
+	//If set, every handled error is recorded on it (see ErrorLog)
+	ErrorLog *ErrorLog
+}
+
+// Entity that recovers panics, traces and logs the errors, and writes a sanitized response
+type Middleware struct {
+	options           Options
+	logFormatter      e2hformat.Formatter
+	responseFormatter e2hformat.Formatter
+}
+
+var defaultMiddleware, _ = NewMiddleware(Options{})
+
+func NewMiddleware(options Options) (*Middleware, error) {
+
+	logFormatter, err := e2hformat.NewFormatter(options.LogFormat)
+	if err != nil {
+		return nil, err
+	}
+	responseFormatter, err := e2hformat.NewFormatter(options.ResponseFormat)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Middleware{
+		options:           options,
+		logFormatter:      logFormatter,
+		responseFormatter: responseFormatter,
+	}, nil
+}
+
+// This function returns a handler that recovers the panics of 'next', handling them as errors
+func (m *Middleware) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		defer m.recoverPanic(rw, r)
+		next.ServeHTTP(rw, r)
+	})
+}
+
+// This function returns a handler that recovers the panics of 'fn' and handles its returned error (if any)
+func (m *Middleware) HandleFunc(fn ErrorHandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rw := &responseWriter{ResponseWriter: w}
+		defer m.recoverPanic(rw, r)
+		if err := fn(rw, r); err != nil {
+			m.WriteError(rw, r, err)
+		}
+	})
+}
+
+// This function traces and logs the error, and writes the response (if it was not already written)
+func (m *Middleware) WriteError(w http.ResponseWriter, r *http.Request, err error) {
+
+	if err == nil {
+		return
+	}
+
+	err = e2h.Tracef(err, "%s %s", r.Method, r.URL.Path)
+
+	status := http.StatusInternalServerError
+	if m.options.StatusMapper != nil {
+		// The invalid codes makes WriteHeader panic
+		if mapped := m.options.StatusMapper(err); mapped >= 100 && mapped <= 999 {
+			status = mapped
+		}
+	}
+
+	trace := m.logFormatter.Format(err, m.options.LogParams)
+	if m.options.Logger != nil {
+		m.options.Logger(r, status, trace)
+	} else {
+		log.Printf("%s %s [%d]: %s", r.Method, r.URL.Path, status, trace)
+	}
+
+	if m.options.ErrorLog != nil {
+		m.options.ErrorLog.Record(err, status)
+	}
+
+	if rw, ok := w.(*responseWriter); ok && rw.wroteHeader {
+		return
+	}
+
+	var body string
+	if m.options.ExposeSource {
+		body = m.responseFormatter.Source(err)
+	} else {
+		body = m.responseFormatter.Source(errors.New(http.StatusText(status)))
+	}
+
+	if m.options.ResponseFormat == e2hformat.Format_JSON {
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	} else {
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	}
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.WriteHeader(status)
+	fmt.Fprintln(w, body)
+}
+
+func (m *Middleware) recoverPanic(w http.ResponseWriter, r *http.Request) {
+
+	recovered := recover()
+	if recovered == nil {
+		return
+	}
+	if recovered == http.ErrAbortHandler {
+		panic(recovered)
+	}
+
+	m.WriteError(w, r, e2h.TracePanic(recovered))
+}
+
+// Wrapper of the http.ResponseWriter that records if the header was already written
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// This function takes over the connection (i.e. WebSocket upgrades), if the wrapped writer supports it.
+// After that, the errors are still logged but no response is written
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, buffer, err := hijacker.Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+
+	return conn, buffer, err
+}
+
+// This function returns the wrapped writer, used by http.ResponseController to reach its features (i.e. deadlines)
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,75 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2hhttp_test its the test package of the net/http integration of the Enhanced Error Handling module
+*/
+package e2hhttp_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2hhttp "github.com/cdleo/go-e2h/http"
+	"github.com/stretchr/testify/require"
+)
+
+// The tests of this file check the traced frames, that are not captured with the 'e2h_nocaller' build tag
+
+func TestMiddleware_HandleFunc_ReturnedError(t *testing.T) {
+
+	// Setup
+	middleware, entries := newTestMiddleware(t, e2hhttp.Options{
+		ResponseFormat: e2hformat.Format_JSON,
+		StatusMapper: func(err error) int {
+			if errors.Is(err.(e2h.EnhancedError).Cause(), errNotFound) {
+				return http.StatusNotFound
+			}
+			return http.StatusInternalServerError
+		},
+	})
+	handler := middleware.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return e2h.Tracem(errNotFound, "loading user 10")
+	})
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/users/10", nil))
+
+	// Check
+	require.Equal(t, http.StatusNotFound, recorder.Code)
+	require.Equal(t, "application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "{\"error\":\"Not Found\"}\n", recorder.Body.String())
+	require.Len(t, *entries, 1)
+	require.Equal(t, http.StatusNotFound, (*entries)[0].status)
+	require.Contains(t, (*entries)[0].trace, "user not found; github.com/cdleo/go-e2h/http_test.TestMiddleware_HandleFunc_ReturnedError.func2 (")
+	require.Contains(t, (*entries)[0].trace, "[loading user 10]; github.com/cdleo/go-e2h/http.(*Middleware).WriteError (")
+	require.Contains(t, (*entries)[0].trace, "[GET /users/10]")
+}
+
+func TestMiddleware_Wrap_RecoversPanic(t *testing.T) {
+
+	// Setup
+	middleware, entries := newTestMiddleware(t, e2hhttp.Options{ExposeSource: true})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		panic("something went wrong")
+	}))
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/orders", nil))
+
+	// Check
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	require.Equal(t, "something went wrong [recovered from panic]\n", recorder.Body.String())
+	require.Len(t, *entries, 1)
+	require.Contains(t, (*entries)[0].trace, "[POST /orders]")
+	require.Contains(t, (*entries)[0].trace, "something went wrong; github.com/cdleo/go-e2h/http_test.TestMiddleware_Wrap_RecoversPanic.func1 (")
+	require.Contains(t, (*entries)[0].trace, "middleware_frames_test.go:59) [recovered from panic];")
+	require.Contains(t, (*entries)[0].trace, "; call stack: github.com/cdleo/go-e2h/http_test.TestMiddleware_Wrap_RecoversPanic.func1 (")
+}
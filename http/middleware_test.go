@@ -0,0 +1,179 @@
+/*
+Package e2hhttp_test its the test package of the net/http integration of the Enhanced Error Handling module
+*/
+package e2hhttp_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2hhttp "github.com/cdleo/go-e2h/http"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("user not found")
+
+type logEntry struct {
+	status int
+	trace  string
+}
+
+func newTestMiddleware(t *testing.T, options e2hhttp.Options) (*e2hhttp.Middleware, *[]logEntry) {
+	entries := make([]logEntry, 0)
+	options.Logger = func(r *http.Request, status int, trace string) {
+		entries = append(entries, logEntry{status: status, trace: trace})
+	}
+	middleware, err := e2hhttp.NewMiddleware(options)
+	require.Nil(t, err)
+	return middleware, &entries
+}
+
+func TestMiddleware_HandleFunc_NoError(t *testing.T) {
+
+	// Setup
+	middleware, entries := newTestMiddleware(t, e2hhttp.Options{})
+	handler := middleware.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+		fmt.Fprint(w, "OK")
+		return nil
+	})
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Check
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.Equal(t, "OK", recorder.Body.String())
+	require.Empty(t, *entries)
+}
+
+func TestMiddleware_Wrap_PanicAfterWrite(t *testing.T) {
+
+	// Setup
+	middleware, entries := newTestMiddleware(t, e2hhttp.Options{})
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic(fmt.Errorf("late failure"))
+	}))
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Check
+	require.Equal(t, http.StatusAccepted, recorder.Code)
+	require.Empty(t, recorder.Body.String())
+	require.Len(t, *entries, 1)
+	require.Contains(t, (*entries)[0].trace, "late failure")
+}
+
+func TestErrorHandlerFunc_ServeHTTP_Defaults(t *testing.T) {
+
+	// Setup
+	handler := e2hhttp.ErrorHandlerFunc(func(w http.ResponseWriter, r *http.Request) error {
+		return e2h.Trace(fmt.Errorf("connection refused"))
+	})
+	recorder := httptest.NewRecorder()
+
+	// Execute
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Check
+	require.Equal(t, http.StatusInternalServerError, recorder.Code)
+	require.Equal(t, "Internal Server Error\n", recorder.Body.String())
+}
+
+func TestMiddleware_HandleFunc_InvalidStatus(t *testing.T) {
+
+	for _, mapped := range []int{0, 99, 1000, -1} {
+		// Setup
+		middleware, entries := newTestMiddleware(t, e2hhttp.Options{
+			StatusMapper: func(err error) int { return mapped },
+		})
+		handler := middleware.HandleFunc(func(w http.ResponseWriter, r *http.Request) error {
+			return errNotFound
+		})
+		recorder := httptest.NewRecorder()
+
+		// Execute
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+		// Check
+		require.Equal(t, http.StatusInternalServerError, recorder.Code)
+		require.Equal(t, "Internal Server Error\n", recorder.Body.String())
+		require.Len(t, *entries, 1)
+		require.Equal(t, http.StatusInternalServerError, (*entries)[0].status)
+	}
+}
+
+func TestNewMiddleware_UnknownFormat(t *testing.T) {
+
+	// Execute
+	middleware, err := e2hhttp.NewMiddleware(e2hhttp.Options{ResponseFormat: e2hformat.Format(99)})
+
+	// Check
+	require.Nil(t, middleware)
+	require.NotNil(t, err)
+}
+
+func TestMiddleware_Wrap_Hijack(t *testing.T) {
+
+	// Setup (the error is logged by the server goroutine, after the client got the response)
+	logged := make(chan logEntry, 1)
+	middleware, err := e2hhttp.NewMiddleware(e2hhttp.Options{
+		Logger: func(r *http.Request, status int, trace string) {
+			logged <- logEntry{status: status, trace: trace}
+		},
+	})
+	require.Nil(t, err)
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, buffer, err := w.(http.Hijacker).Hijack()
+		require.Nil(t, err)
+		defer conn.Close()
+		buffer.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: websocket\r\nConnection: Upgrade\r\n\r\n")
+		buffer.Flush()
+		panic(errors.New("connection lost"))
+	}))
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	// Execute
+	response, err := http.Get(server.URL)
+
+	// Check
+	require.Nil(t, err)
+	response.Body.Close()
+	require.Equal(t, http.StatusSwitchingProtocols, response.StatusCode)
+	select {
+	case entry := <-logged:
+		require.Contains(t, entry.trace, "connection lost")
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the error was not logged")
+	}
+}
+
+func TestMiddleware_Wrap_Unwrap(t *testing.T) {
+
+	// Setup
+	middleware, _ := newTestMiddleware(t, e2hhttp.Options{})
+	recorder := httptest.NewRecorder()
+	var unwrapped http.ResponseWriter
+	var hijackErr error
+	handler := middleware.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		unwrapped = w.(interface{ Unwrap() http.ResponseWriter }).Unwrap()
+		_, _, hijackErr = w.(http.Hijacker).Hijack()
+	}))
+
+	// Execute
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Check
+	require.Equal(t, recorder, unwrapped)
+	require.Equal(t, http.ErrNotSupported, hijackErr)
+}
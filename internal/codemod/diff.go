@@ -0,0 +1,180 @@
+/*
+Package codemod is the source rewriting package used by the commands of the Enhanced Error Handling module
+*/
+package codemod
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of unchanged lines shown around each change
+const diffContextLines = 3
+
+// Kind of a diff line
+type lineOp byte
+
+const (
+	lineOp_Equal  lineOp = ' '
+	lineOp_Delete lineOp = '-'
+	lineOp_Insert lineOp = '+'
+)
+
+type diffLine struct {
+	op   lineOp
+	text string
+	// Line numbers (1-based) on the old and new texts
+	oldLine int
+	newLine int
+}
+
+// This function returns the unified diff between the old and new texts, or an empty string if they are equal
+func Diff(name string, oldText []byte, newText []byte) string {
+
+	lines := diffLines(splitLines(string(oldText)), splitLines(string(newText)))
+
+	var result strings.Builder
+	for start := 0; start < len(lines); {
+		// Look for the next change, and the end of its hunk
+		first := start
+		for first < len(lines) && lines[first].op == lineOp_Equal {
+			first++
+		}
+		if first == len(lines) {
+			break
+		}
+		last := first
+		for i := first; i < len(lines); i++ {
+			if lines[i].op != lineOp_Equal {
+				last = i
+			} else if i-last > 2*diffContextLines {
+				break
+			}
+		}
+
+		from := max(first-diffContextLines, start)
+		to := min(last+diffContextLines+1, len(lines))
+		if result.Len() == 0 {
+			fmt.Fprintf(&result, "--- a/%s\n+++ b/%s\n", name, name)
+		}
+		writeHunk(&result, lines[from:to])
+		start = to
+	}
+
+	return result.String()
+}
+
+func writeHunk(result *strings.Builder, lines []diffLine) {
+
+	var oldCount, newCount int
+	for _, line := range lines {
+		if line.op != lineOp_Insert {
+			oldCount++
+		}
+		if line.op != lineOp_Delete {
+			newCount++
+		}
+	}
+
+	oldStart, newStart := lines[0].oldLine, lines[0].newLine
+	if oldCount == 0 {
+		oldStart--
+	}
+	if newCount == 0 {
+		newStart--
+	}
+	fmt.Fprintf(result, "@@ -%d,%d +%d,%d @@\n", oldStart, oldCount, newStart, newCount)
+	for _, line := range lines {
+		fmt.Fprintf(result, "%c%s\n", line.op, line.text)
+	}
+}
+
+// This function returns the edit script between both line sets, based on their longest common subsequence
+func diffLines(oldLines []string, newLines []string) []diffLine {
+
+	// The common prefix and suffix are excluded from the LCS table
+	prefix := 0
+	for prefix < len(oldLines) && prefix < len(newLines) && oldLines[prefix] == newLines[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldLines)-prefix && suffix < len(newLines)-prefix &&
+		oldLines[len(oldLines)-1-suffix] == newLines[len(newLines)-1-suffix] {
+		suffix++
+	}
+	oldMiddle := oldLines[prefix : len(oldLines)-suffix]
+	newMiddle := newLines[prefix : len(newLines)-suffix]
+
+	lcs := make([][]int, len(oldMiddle)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(newMiddle)+1)
+	}
+	for i := len(oldMiddle) - 1; i >= 0; i-- {
+		for j := len(newMiddle) - 1; j >= 0; j-- {
+			if oldMiddle[i] == newMiddle[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	lines := make([]diffLine, 0, len(oldLines)+len(newLines))
+	oldLine, newLine := 1, 1
+	add := func(op lineOp, text string) {
+		lines = append(lines, diffLine{op: op, text: text, oldLine: oldLine, newLine: newLine})
+		if op != lineOp_Insert {
+			oldLine++
+		}
+		if op != lineOp_Delete {
+			newLine++
+		}
+	}
+
+	for _, line := range oldLines[:prefix] {
+		add(lineOp_Equal, line)
+	}
+	i, j := 0, 0
+	for i < len(oldMiddle) || j < len(newMiddle) {
+		switch {
+		case i < len(oldMiddle) && j < len(newMiddle) && oldMiddle[i] == newMiddle[j]:
+			add(lineOp_Equal, oldMiddle[i])
+			i++
+			j++
+		case j < len(newMiddle) && (i == len(oldMiddle) || lcs[i][j+1] > lcs[i+1][j]):
+			add(lineOp_Insert, newMiddle[j])
+			j++
+		default:
+			add(lineOp_Delete, oldMiddle[i])
+			i++
+		}
+	}
+	for _, line := range oldLines[len(oldLines)-suffix:] {
+		add(lineOp_Equal, line)
+	}
+
+	return lines
+}
+
+func splitLines(text string) []string {
+
+	if len(text) == 0 {
+		return nil
+	}
+
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func max(a int, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,276 @@
+/*
+Package codemod is the source rewriting package used by the commands of the Enhanced Error Handling module
+*/
+package codemod
+
+import (
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Entity with a replacement of the source bytes [Start, End)
+type Edit struct {
+	Start int
+	End   int
+	Text  string
+}
+
+// Entity with a construct that could not be rewritten
+type Warning struct {
+	Position token.Position
+	Message  string
+}
+
+// Entity with a parsed source file and the edits to apply on it. The edits are applied on the original
+// source text (and then formatted), so the comments and formatting of the untouched code are preserved
+type File struct {
+	Fset     *token.FileSet
+	AST      *ast.File
+	Src      []byte
+	edits    []Edit
+	warnings []Warning
+	imports  []string
+}
+
+// This function parses the source file. It returns nil if the file is generated
+func ParseFile(name string, src []byte) (*File, error) {
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	if IsGenerated(file) {
+		return nil, nil
+	}
+
+	return &File{
+		Fset: fset,
+		AST:  file,
+		Src:  src,
+	}, nil
+}
+
+// This function returns true if the file has the standard "Code generated ... DO NOT EDIT." comment
+func IsGenerated(file *ast.File) bool {
+
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			return false
+		}
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, "// Code generated ") && strings.HasSuffix(comment.Text, " DO NOT EDIT.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// This function returns the byte offset of the position
+func (f *File) Offset(pos token.Pos) int {
+	return f.Fset.Position(pos).Offset
+}
+
+// This function returns the source text of the node
+func (f *File) Text(node ast.Node) string {
+	return string(f.Src[f.Offset(node.Pos()):f.Offset(node.End())])
+}
+
+// This function replaces the source text of the node
+func (f *File) Replace(node ast.Node, text string) {
+	f.edits = append(f.edits, Edit{Start: f.Offset(node.Pos()), End: f.Offset(node.End()), Text: text})
+}
+
+// This function wraps the source text of the node with the prefix and suffix
+func (f *File) Wrap(node ast.Node, prefix string, suffix string) {
+	f.edits = append(f.edits,
+		Edit{Start: f.Offset(node.Pos()), End: f.Offset(node.Pos()), Text: prefix},
+		Edit{Start: f.Offset(node.End()), End: f.Offset(node.End()), Text: suffix})
+}
+
+// This function records a construct that could not be rewritten
+func (f *File) Warnf(pos token.Pos, format string, args ...interface{}) {
+	f.warnings = append(f.warnings, Warning{Position: f.Fset.Position(pos), Message: fmt.Sprintf(format, args...)})
+}
+
+// This function returns the warnings recorded on the file
+func (f *File) Warnings() []Warning {
+	return f.warnings
+}
+
+// This function returns true if there are edits to apply
+func (f *File) Changed() bool {
+	return len(f.edits) > 0
+}
+
+// This function returns the name to use for the imported package, adding the import if the file doesn't have it
+func (f *File) AddImport(importPath string, name string) string {
+
+	if spec := f.findImport(importPath); spec != nil {
+		if spec.Name != nil {
+			return spec.Name.Name
+		}
+		return name
+	}
+	for _, item := range f.imports {
+		if item == importPath {
+			return name
+		}
+	}
+	f.imports = append(f.imports, importPath)
+
+	return name
+}
+
+// This function returns the local name of the imported package, or an empty string if it's not imported
+func (f *File) ImportName(importPath string, name string) string {
+
+	spec := f.findImport(importPath)
+	if spec == nil {
+		return ""
+	}
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+
+	return name
+}
+
+// This function removes the import of the package
+func (f *File) RemoveImport(importPath string) {
+
+	for _, decl := range f.AST.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.IMPORT {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			importSpec := spec.(*ast.ImportSpec)
+			if unquote(importSpec.Path.Value) != importPath {
+				continue
+			}
+			if len(genDecl.Specs) == 1 {
+				f.removeLines(genDecl)
+			} else {
+				f.removeLines(importSpec)
+			}
+			return
+		}
+	}
+}
+
+// This function returns the rewritten and formatted source
+func (f *File) Apply() ([]byte, error) {
+
+	edits := append([]Edit(nil), f.edits...)
+	if len(f.imports) > 0 {
+		edits = append(edits, f.importEdit())
+	}
+	sort.SliceStable(edits, func(i, j int) bool {
+		return edits[i].Start < edits[j].Start
+	})
+
+	var result strings.Builder
+	last := 0
+	for _, edit := range edits {
+		if edit.Start < last {
+			return nil, fmt.Errorf("%s: overlapping edits at offset %d", f.Fset.File(f.AST.Pos()).Name(), edit.Start)
+		}
+		result.Write(f.Src[last:edit.Start])
+		result.WriteString(edit.Text)
+		last = edit.End
+	}
+	result.Write(f.Src[last:])
+
+	return format.Source([]byte(result.String()))
+}
+
+func (f *File) findImport(importPath string) *ast.ImportSpec {
+
+	for _, spec := range f.AST.Imports {
+		if unquote(spec.Path.Value) == importPath {
+			return spec
+		}
+	}
+
+	return nil
+}
+
+// This function returns the edit that adds the pending imports into the last import declaration. They're added
+// on a new group, unless the last group already has non standard library packages
+func (f *File) importEdit() Edit {
+
+	var specs []string
+	for _, item := range f.imports {
+		specs = append(specs, strconv.Quote(item))
+	}
+
+	var last *ast.GenDecl
+	for _, decl := range f.AST.Decls {
+		if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+			last = genDecl
+		}
+	}
+
+	if last == nil {
+		offset := f.Offset(f.AST.Name.End())
+		if len(specs) == 1 {
+			return Edit{Start: offset, End: offset, Text: fmt.Sprintf("\n\nimport %s", specs[0])}
+		}
+		return Edit{Start: offset, End: offset, Text: fmt.Sprintf("\n\nimport (\n\t%s\n)", strings.Join(specs, "\n\t"))}
+	}
+
+	separator := ""
+	if lastSpec := last.Specs[len(last.Specs)-1].(*ast.ImportSpec); isStandardPackage(unquote(lastSpec.Path.Value)) {
+		separator = "\n"
+	}
+	if last.Rparen.IsValid() {
+		offset := f.Offset(last.Rparen)
+		return Edit{Start: offset, End: offset, Text: fmt.Sprintf("%s\t%s\n", separator, strings.Join(specs, "\n\t"))}
+	}
+
+	// Single import declaration, converted into a grouped one
+	return Edit{
+		Start: f.Offset(last.Pos()),
+		End:   f.Offset(last.End()),
+		Text:  fmt.Sprintf("import (\n\t%s\n%s\t%s\n)", f.Text(last.Specs[0]), separator, strings.Join(specs, "\n\t")),
+	}
+}
+
+// This function returns true if the import path belongs to the standard library (there is no dot on its first element)
+func isStandardPackage(importPath string) bool {
+	return !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".")
+}
+
+// This function removes the lines of the node, if there is nothing else on them
+func (f *File) removeLines(node ast.Node) {
+
+	start, end := f.Offset(node.Pos()), f.Offset(node.End())
+	lineStart := strings.LastIndexByte(string(f.Src[:start]), '\n') + 1
+	if len(strings.TrimSpace(string(f.Src[lineStart:start]))) == 0 {
+		start = lineStart
+	}
+	if lineEnd := strings.IndexByte(string(f.Src[end:]), '\n'); lineEnd >= 0 && len(strings.TrimSpace(string(f.Src[end:end+lineEnd]))) == 0 {
+		end += lineEnd + 1
+	}
+
+	f.edits = append(f.edits, Edit{Start: start, End: end})
+}
+
+func unquote(value string) string {
+
+	unquoted, err := strconv.Unquote(value)
+	if err != nil {
+		return value
+	}
+
+	return unquoted
+}
@@ -0,0 +1,97 @@
+/*
+Package codemod is the source rewriting package used by the commands of the Enhanced Error Handling module
+*/
+package codemod
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Function that records the edits (and warnings) of a parsed file
+type Rewriter func(file *File)
+
+// Entity with the options of a rewriting run
+type Options struct {
+	//Writes the unified diff of each changed file into the output, instead of rewriting it
+	Diff bool
+	//Output for the diffs
+	Stdout io.Writer
+	//Output for the warnings
+	Stderr io.Writer
+}
+
+// This function rewrites the Go files of the provided paths. The directories are walked recursively
+// (skipping the vendor, testdata and hidden ones), and a trailing "/..." is accepted, as in the go command.
+// It returns the number of warnings reported
+func Run(paths []string, rewrite Rewriter, options Options) (int, error) {
+
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	warnings := 0
+	for _, root := range paths {
+		root = strings.TrimSuffix(root, "/...")
+		if root == "" {
+			root = "."
+		}
+		err := filepath.Walk(root, func(name string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				base := info.Name()
+				if name != root && (base == "vendor" || base == "testdata" || strings.HasPrefix(base, ".") || strings.HasPrefix(base, "_")) {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(name, ".go") {
+				return nil
+			}
+			count, err := rewriteFile(name, info.Mode(), rewrite, options)
+			warnings += count
+			return err
+		})
+		if err != nil {
+			return warnings, err
+		}
+	}
+
+	return warnings, nil
+}
+
+func rewriteFile(name string, mode os.FileMode, rewrite Rewriter, options Options) (int, error) {
+
+	src, err := os.ReadFile(name)
+	if err != nil {
+		return 0, err
+	}
+	file, err := ParseFile(name, src)
+	if err != nil || file == nil {
+		return 0, err
+	}
+
+	rewrite(file)
+	for _, warning := range file.Warnings() {
+		fmt.Fprintf(options.Stderr, "%s: %s\n", warning.Position, warning.Message)
+	}
+	if !file.Changed() {
+		return len(file.Warnings()), nil
+	}
+
+	result, err := file.Apply()
+	if err != nil {
+		return len(file.Warnings()), err
+	}
+	if options.Diff {
+		_, err = io.WriteString(options.Stdout, Diff(filepath.ToSlash(name), src, result))
+		return len(file.Warnings()), err
+	}
+
+	return len(file.Warnings()), os.WriteFile(name, result, mode.Perm())
+}
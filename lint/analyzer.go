@@ -0,0 +1,408 @@
+/*
+Package e2hlint is the static analysis package of the Enhanced Error Handling module
+*/
+package e2hlint
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// Import path of the package with the tracing functions
+const e2hPackage = "github.com/cdleo/go-e2h"
+
+// Tracing functions of the e2h package, with the index of its error argument
+var tracers = map[string]int{
+	"Trace":     0,
+	"Tracem":    0,
+	"Tracef":    0,
+	"TraceCtx":  1,
+	"TracemCtx": 1,
+	"TracefCtx": 1,
+	"Trace2":    1,
+}
+
+// Functions of the e2h package that return a new error, already traced
+var constructors = map[string]bool{
+	"New":    true,
+	"Errorf": true,
+}
+
+// Tracing functions with a format argument, following the error one
+var formatTracers = map[string]bool{
+	"Tracef":    true,
+	"TracefCtx": true,
+}
+
+// Analyzer that reports untraced error returns, repeated traces of the same error within one function
+// and Tracef calls with a format that doesn't match its arguments
+var Analyzer = &analysis.Analyzer{
+	Name: "e2hlint",
+	Doc: "check the usage of the Enhanced Error Handling module\n\n" +
+		"Reports the errors returned from a call without being traced with e2h.Trace, e2h.Tracem or e2h.Tracef\n" +
+		"(or its Ctx variants), the errors traced more than once within the same function, and the e2h.Tracef calls\n" +
+		"whose format verbs doesn't match the provided arguments.",
+	Run: run,
+}
+
+// Entity with the value assigned to a variable
+type assignment struct {
+	end   token.Pos
+	value ast.Expr
+}
+
+// Entity with the info of the function being analyzed
+type function struct {
+	pass        *analysis.Pass
+	signature   *types.Signature
+	assignments map[types.Object][]assignment
+}
+
+func run(pass *analysis.Pass) (interface{}, error) {
+
+	for _, file := range pass.Files {
+		if isGenerated(file) {
+			continue
+		}
+		ast.Inspect(file, func(node ast.Node) bool {
+			switch node := node.(type) {
+			case *ast.FuncDecl:
+				if node.Body != nil {
+					if signature, ok := pass.TypesInfo.ObjectOf(node.Name).Type().(*types.Signature); ok {
+						checkFunction(pass, signature, node.Body)
+					}
+				}
+			case *ast.FuncLit:
+				if signature, ok := pass.TypesInfo.TypeOf(node).(*types.Signature); ok {
+					checkFunction(pass, signature, node.Body)
+				}
+			}
+			return true
+		})
+	}
+
+	return nil, nil
+}
+
+// This function checks the statements of a function body. The nested function literals are checked on its own
+func checkFunction(pass *analysis.Pass, signature *types.Signature, body *ast.BlockStmt) {
+
+	fn := &function{
+		pass:        pass,
+		signature:   signature,
+		assignments: make(map[types.Object][]assignment),
+	}
+
+	var returns []*ast.ReturnStmt
+	var calls []*ast.CallExpr
+	deferredWrap := false
+	ast.Inspect(body, func(node ast.Node) bool {
+		switch node := node.(type) {
+		case *ast.FuncLit:
+			return false
+		case *ast.DeferStmt:
+			// The returned errors are traced on exit by: defer e2h.Wrap(&err, ...)
+			deferredWrap = deferredWrap || e2hFuncName(pass, node.Call) == "Wrap"
+		case *ast.AssignStmt:
+			fn.addAssignments(node.Lhs, node.Rhs, node.End())
+		case *ast.ValueSpec:
+			names := make([]ast.Expr, 0, len(node.Names))
+			for _, name := range node.Names {
+				names = append(names, name)
+			}
+			fn.addAssignments(names, node.Values, node.End())
+		case *ast.ReturnStmt:
+			returns = append(returns, node)
+		case *ast.CallExpr:
+			if len(tracerName(pass, node)) > 0 {
+				calls = append(calls, node)
+			}
+		}
+		return true
+	})
+
+	for _, stmt := range returns {
+		if !deferredWrap {
+			fn.checkReturn(stmt)
+		}
+	}
+	for _, call := range calls {
+		fn.checkTrace(call)
+	}
+}
+
+func (fn *function) addAssignments(lhs []ast.Expr, rhs []ast.Expr, end token.Pos) {
+
+	for i, expr := range lhs {
+		ident, ok := expr.(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+		object := fn.pass.TypesInfo.ObjectOf(ident)
+		if object == nil {
+			continue
+		}
+		var value ast.Expr
+		switch {
+		case len(lhs) == len(rhs):
+			value = rhs[i]
+		case len(rhs) == 1:
+			value = rhs[0]
+		}
+		fn.assignments[object] = append(fn.assignments[object], assignment{end: end, value: value})
+	}
+}
+
+// This function returns the value of the last assignment to the variable before the position (or nil, if unknown)
+func (fn *function) lastValue(ident *ast.Ident, pos token.Pos) ast.Expr {
+
+	var value ast.Expr
+	for _, item := range fn.assignments[fn.pass.TypesInfo.ObjectOf(ident)] {
+		if item.end <= pos {
+			value = item.value
+		}
+	}
+	if value == nil {
+		return nil
+	}
+
+	return ast.Unparen(value)
+}
+
+// This function reports the errors returned from a call, without being traced
+func (fn *function) checkReturn(stmt *ast.ReturnStmt) {
+
+	results := fn.signature.Results()
+	if len(stmt.Results) != results.Len() {
+		return
+	}
+
+	for i, result := range stmt.Results {
+		if !isError(results.At(i).Type()) {
+			continue
+		}
+		ident, ok := ast.Unparen(result).(*ast.Ident)
+		if !ok {
+			continue
+		}
+		call, ok := fn.lastValue(ident, stmt.Pos()).(*ast.CallExpr)
+		if !ok || len(tracedBy(fn.pass, call)) > 0 {
+			continue
+		}
+		fn.pass.Reportf(ident.Pos(), "error returned from %s is not traced, use e2h.Trace(%s)", callName(call), ident.Name)
+	}
+}
+
+// This function reports the errors traced more than once within the function, and checks the Tracef format
+func (fn *function) checkTrace(call *ast.CallExpr) {
+
+	name := tracerName(fn.pass, call)
+	errIndex := tracers[name]
+	if len(call.Args) <= errIndex {
+		return
+	}
+
+	argument := ast.Unparen(call.Args[errIndex])
+	if ident, ok := argument.(*ast.Ident); ok {
+		if value := fn.lastValue(ident, call.Pos()); value != nil {
+			argument = value
+		}
+	}
+	if traced, ok := argument.(*ast.CallExpr); ok {
+		if previous := tracedBy(fn.pass, traced); len(previous) > 0 {
+			fn.pass.Reportf(call.Pos(), "error already traced with e2h.%s in this function, it should be traced only once", previous)
+		}
+	}
+
+	if formatTracers[name] && len(call.Args) > errIndex+1 {
+		checkFormat(fn.pass, call, name, errIndex+1)
+	}
+}
+
+// This function returns the name of the e2h tracing function called, or an empty string if it's not one of them
+func tracerName(pass *analysis.Pass, call *ast.CallExpr) string {
+
+	name := e2hFuncName(pass, call)
+	if _, ok := tracers[name]; !ok {
+		return ""
+	}
+
+	return name
+}
+
+// This function returns the name of the e2h function called, if it returns a traced error (a tracing function
+// or a constructor), or an empty string otherwise
+func tracedBy(pass *analysis.Pass, call *ast.CallExpr) string {
+
+	if name := e2hFuncName(pass, call); constructors[name] {
+		return name
+	}
+
+	return tracerName(pass, call)
+}
+
+// This function returns the name of the e2h package function called, or an empty string if it's not one of them
+func e2hFuncName(pass *analysis.Pass, call *ast.CallExpr) string {
+
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return ""
+	}
+
+	object, ok := pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || object.Pkg() == nil || object.Pkg().Path() != e2hPackage {
+		return ""
+	}
+
+	return object.Name()
+}
+
+// This function returns a readable name of the called function
+func callName(call *ast.CallExpr) string {
+
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		return fun.Name
+	case *ast.SelectorExpr:
+		if x, ok := fun.X.(*ast.Ident); ok {
+			return x.Name + "." + fun.Sel.Name
+		}
+		return fun.Sel.Name
+	}
+
+	return "call"
+}
+
+func isError(t types.Type) bool {
+	return types.Identical(t, types.Universe.Lookup("error").Type())
+}
+
+// This function returns true if the file has the standard "Code generated ... DO NOT EDIT." comment
+func isGenerated(file *ast.File) bool {
+
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			return false
+		}
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, "// Code generated ") && strings.HasSuffix(comment.Text, " DO NOT EDIT.") {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// This function reports the Tracef calls with a constant format that doesn't match its arguments
+func checkFormat(pass *analysis.Pass, call *ast.CallExpr, name string, formatIndex int) {
+
+	format := pass.TypesInfo.Types[call.Args[formatIndex]].Value
+	if format == nil || format.Kind() != constant.String {
+		return
+	}
+	verbs, ok := parseVerbs(constant.StringVal(format))
+	if !ok {
+		return
+	}
+
+	args := call.Args[formatIndex+1:]
+	for i, verb := range verbs {
+		if verb == 'w' {
+			pass.Reportf(call.Args[formatIndex].Pos(), "e2h.%s doesn't support the %%w verb, use %%v instead", name)
+			return
+		}
+		if call.Ellipsis.IsValid() || i >= len(args) {
+			continue
+		}
+		if argType := pass.TypesInfo.TypeOf(args[i]); argType != nil && !matchVerb(verb, argType) {
+			pass.Reportf(args[i].Pos(), "e2h.%s format %%%c has arg %s of wrong type %s", name, verb, types.ExprString(args[i]), argType)
+		}
+	}
+
+	if !call.Ellipsis.IsValid() && len(verbs) != len(args) {
+		pass.Reportf(call.Pos(), "e2h.%s format %s reads %d arg(s), but call has %d arg(s)", name, types.ExprString(call.Args[formatIndex]), len(verbs), len(args))
+	}
+}
+
+// This function returns the verbs of a format, each one consuming an argument ('*' for the width and
+// precision arguments). It returns false if the format uses explicit argument indexes
+func parseVerbs(format string) ([]rune, bool) {
+
+	verbs := make([]rune, 0)
+	runes := []rune(format)
+	for i := 0; i < len(runes); i++ {
+		if runes[i] != '%' {
+			continue
+		}
+		i++
+		for i < len(runes) && strings.ContainsRune("+-# 0", runes[i]) {
+			i++
+		}
+		for i < len(runes) && (runes[i] == '*' || runes[i] == '.' || (runes[i] >= '0' && runes[i] <= '9')) {
+			if runes[i] == '*' {
+				verbs = append(verbs, '*')
+			}
+			i++
+		}
+		if i >= len(runes) {
+			break
+		}
+		if runes[i] == '[' {
+			return nil, false
+		}
+		if runes[i] != '%' {
+			verbs = append(verbs, runes[i])
+		}
+	}
+
+	return verbs, true
+}
+
+// This function returns false only if the argument type is known to be wrong for the verb
+func matchVerb(verb rune, argType types.Type) bool {
+
+	basic, ok := argType.Underlying().(*types.Basic)
+	if !ok {
+		return true
+	}
+
+	info := basic.Info()
+	if verb == '*' {
+		return info&types.IsInteger != 0
+	}
+	if hasMethod(argType, "Error") || hasMethod(argType, "String") {
+		return true
+	}
+
+	switch verb {
+	case 'd', 'c', 'U':
+		return info&types.IsInteger != 0
+	case 's', 'q':
+		return info&types.IsString != 0 || (verb == 'q' && info&types.IsInteger != 0)
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		return info&(types.IsFloat|types.IsComplex) != 0
+	case 't':
+		return info&types.IsBoolean != 0
+	}
+
+	return true
+}
+
+func hasMethod(t types.Type, name string) bool {
+
+	object, _, _ := types.LookupFieldOrMethod(t, true, nil, name)
+	_, ok := object.(*types.Func)
+
+	return ok
+}
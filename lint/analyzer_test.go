@@ -0,0 +1,15 @@
+/*
+Package e2hlint_test its the test package of the static analysis of the Enhanced Error Handling module
+*/
+package e2hlint_test
+
+import (
+	"testing"
+
+	e2hlint "github.com/cdleo/go-e2h/lint"
+	"golang.org/x/tools/go/analysis/analysistest"
+)
+
+func TestAnalyzer(t *testing.T) {
+	analysistest.Run(t, analysistest.TestData(), e2hlint.Analyzer, "a")
+}
@@ -0,0 +1,14 @@
+/*
+Command e2hlint checks the usage of the Enhanced Error Handling module. It could be run standalone
+(e2hlint ./...) or as a vet tool (go vet -vettool=$(which e2hlint) ./...)
+*/
+package main
+
+import (
+	e2hlint "github.com/cdleo/go-e2h/lint"
+	"golang.org/x/tools/go/analysis/singlechecker"
+)
+
+func main() {
+	singlechecker.Main(e2hlint.Analyzer)
+}
@@ -0,0 +1,142 @@
+package a
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/cdleo/go-e2h"
+)
+
+type user struct {
+	name string
+}
+
+func (u user) String() string { return u.name }
+
+func open(name string) error {
+	_, err := os.Open(name)
+	return err // want `error returned from os.Open is not traced, use e2h.Trace\(err\)`
+}
+
+func openTraced(name string) error {
+	_, err := os.Open(name)
+	if err != nil {
+		return e2h.Tracem(err, "opening file")
+	}
+	return nil
+}
+
+func openReassigned(name string) (int, error) {
+	_, err := os.Open(name)
+	err = e2h.Trace(err)
+	return 0, err
+}
+
+func openInit(name string) (*os.File, error) {
+	if file, err := os.Open(name); err != nil {
+		return nil, err // want `error returned from os.Open is not traced, use e2h.Trace\(err\)`
+	} else {
+		return file, nil
+	}
+}
+
+func fromParameter(err error) error {
+	return err
+}
+
+func sentinel() error {
+	var err error = errNotFound
+	return err
+}
+
+var errNotFound = errors.New("not found")
+
+func closure() func() error {
+	return func() error {
+		err := open("file")
+		return err // want `error returned from open is not traced, use e2h.Trace\(err\)`
+	}
+}
+
+func doubleTrace(name string) error {
+	err := e2h.Trace(open(name))
+	return e2h.Tracem(err, "opening") // want `error already traced with e2h.Trace in this function, it should be traced only once`
+}
+
+func nestedTrace(name string) error {
+	return e2h.Trace(e2h.Tracef(open(name), "opening %s", name)) // want `error already traced with e2h.Tracef in this function, it should be traced only once`
+}
+
+func formats(name string, id int, u user, args []interface{}) error {
+	err := open(name)
+	if id == 0 {
+		return e2h.Tracef(err, "opening %s (%d)", name) // want `e2h.Tracef format "opening %s \(%d\)" reads 2 arg\(s\), but call has 1 arg\(s\)`
+	}
+	if id == 1 {
+		return e2h.Tracef(err, "opening %d", name) // want `e2h.Tracef format %d has arg name of wrong type string`
+	}
+	if id == 2 {
+		return e2h.Tracef(err, "opening: %w", err) // want `e2h.Tracef doesn't support the %w verb, use %v instead`
+	}
+	if id == 3 {
+		return e2h.Tracef(err, "opening %s as %s, %v%%", name, u, id)
+	}
+	if id == 4 {
+		return e2h.Tracef(err, "opening %[1]s", name)
+	}
+	if id == 5 {
+		return e2h.Tracef(err, "opening %*d", id, id)
+	}
+	return e2h.Tracef(err, fmt.Sprint("opening ", name), args...)
+}
+
+func withContext(ctx context.Context, name string) error {
+	err := e2h.TraceCtx(ctx, open(name))
+	if name == "" {
+		return e2h.TracefCtx(ctx, err, "opening %d", name) // want `error already traced with e2h.TraceCtx in this function, it should be traced only once` `e2h.TracefCtx format %d has arg name of wrong type string`
+	}
+	return err
+}
+
+func deferredWrap(name string) (err error) {
+	defer e2h.Wrap(&err, "opening %s", name)
+	_, err = os.Open(name)
+	return err
+}
+
+func traceInline(name string) (*os.File, error) {
+	file, err := e2h.Trace2(os.Open(name))
+	return file, err
+}
+
+func traceInlineTwice(name string) (*os.File, error) {
+	file, err := os.Open(name)
+	return e2h.Trace2(file, e2h.Trace(err)) // want `error already traced with e2h.Trace in this function, it should be traced only once`
+}
+
+func constructed(name string) error {
+	err := e2h.Errorf("opening %s: %w", name, os.ErrNotExist)
+	if name == "" {
+		err = e2h.New("empty name")
+	}
+	return err
+}
+
+func constructedTwice(name string) error {
+	err := e2h.New("empty name")
+	return e2h.Tracem(err, "opening") // want `error already traced with e2h.New in this function, it should be traced only once`
+}
+
+var errUserNotFound = e2h.Define("USER_NOT_FOUND", "user %s not found")
+
+func defined(name string) error {
+	err := errUserNotFound.New(name)
+	return err
+}
+
+func definedTwice(name string) error {
+	err := errUserNotFound.New(name)
+	return e2h.Trace(err) // want `error already traced with e2h.New in this function, it should be traced only once`
+}
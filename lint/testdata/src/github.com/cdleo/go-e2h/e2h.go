@@ -0,0 +1,32 @@
+// Package e2h is a stub of the Enhanced Error Handling module, with the API used by the analyzer fixtures
+package e2h
+
+import "context"
+
+func New(message string) error { return nil }
+
+func Errorf(format string, args ...interface{}) error { return nil }
+
+func Trace(e error) error { return e }
+
+func Tracem(e error, message string) error { return e }
+
+func Tracef(e error, format string, args ...interface{}) error { return e }
+
+func TraceCtx(ctx context.Context, e error) error { return e }
+
+func TracemCtx(ctx context.Context, e error, message string) error { return e }
+
+func TracefCtx(ctx context.Context, e error, format string, args ...interface{}) error { return e }
+
+func Wrap(err *error, format string, args ...interface{}) {}
+
+func Trace2[T any](v T, err error) (T, error) { return v, err }
+
+type Definition struct{}
+
+func Define(code string, template string) *Definition { return &Definition{} }
+
+func (d *Definition) Error() string { return "" }
+
+func (d *Definition) New(args ...interface{}) error { return nil }
@@ -0,0 +1,7 @@
+/*
+Package e2hslog is the log/slog integration package of the Enhanced Error Handling module.
+
+It requires Go 1.21 or later: its Handler wraps another slog.Handler, expanding the error attributes
+through an e2hformat formatter.
+*/
+package e2hslog
@@ -0,0 +1,115 @@
+//go:build go1.21
+// +build go1.21
+
+/*
+Package e2hslog is the log/slog integration package of the Enhanced Error Handling module
+*/
+package e2hslog
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+type Options struct {
+	//Format used to expand the errors. With Format_JSON the trace is logged as a nested object (on JSON handlers)
+	Format e2hformat.Format
+	//Params used to expand the errors
+	Params e2hformat.Params
+}
+
+// Entity that wraps a slog.Handler, expanding any error attribute (even within groups) through a formatter
+type Handler struct {
+	next      slog.Handler
+	options   Options
+	formatter e2hformat.Formatter
+}
+
+// This function returns a handler that expands the error attributes before passing the records to 'next'
+func NewHandler(next slog.Handler, options Options) (*Handler, error) {
+
+	formatter, err := e2hformat.NewFormatter(options.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Handler{
+		next:      next,
+		options:   options,
+		formatter: formatter,
+	}, nil
+}
+
+// This function reports whether the wrapped handler handles records at the given level
+func (h *Handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+// This function expands the error attributes of the record, and passes it to the wrapped handler
+func (h *Handler) Handle(ctx context.Context, record slog.Record) error {
+
+	expanded := slog.NewRecord(record.Time, record.Level, record.Message, record.PC)
+	record.Attrs(func(attr slog.Attr) bool {
+		expanded.AddAttrs(h.expand(attr))
+		return true
+	})
+
+	return h.next.Handle(ctx, expanded)
+}
+
+// This function returns a handler with the attributes (with its errors expanded) added to the wrapped one
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+
+	expanded := make([]slog.Attr, 0, len(attrs))
+	for _, attr := range attrs {
+		expanded = append(expanded, h.expand(attr))
+	}
+
+	return h.wrap(h.next.WithAttrs(expanded))
+}
+
+// This function returns a handler with the group added to the wrapped one
+func (h *Handler) WithGroup(name string) slog.Handler {
+	return h.wrap(h.next.WithGroup(name))
+}
+
+func (h *Handler) wrap(next slog.Handler) *Handler {
+	return &Handler{
+		next:      next,
+		options:   h.options,
+		formatter: h.formatter,
+	}
+}
+
+// This function replaces the error value of the attribute (or of the attributes of a group) with its formatted trace
+func (h *Handler) expand(attr slog.Attr) slog.Attr {
+
+	switch attr.Value.Kind() {
+	case slog.KindAny, slog.KindLogValuer:
+		err, ok := attr.Value.Any().(error)
+		if !ok || err == nil {
+			return attr
+		}
+		trace := h.formatter.Format(err, h.options.Params)
+		if len(trace) == 0 {
+			return slog.String(attr.Key, err.Error())
+		}
+		if h.options.Format == e2hformat.Format_JSON {
+			return slog.Any(attr.Key, json.RawMessage(trace))
+		}
+		return slog.String(attr.Key, trace)
+
+	case slog.KindGroup:
+		group := attr.Value.Group()
+		expanded := make([]slog.Attr, 0, len(group))
+		for _, item := range group {
+			expanded = append(expanded, h.expand(item))
+		}
+		return slog.Attr{Key: attr.Key, Value: slog.GroupValue(expanded...)}
+	}
+
+	return attr
+}
@@ -0,0 +1,92 @@
+//go:build go1.21 && !e2h_nocaller
+// +build go1.21,!e2h_nocaller
+
+/*
+Package e2hslog_test its the test package of the log/slog integration of the Enhanced Error Handling module
+*/
+package e2hslog_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2hslog "github.com/cdleo/go-e2h/slog"
+	"github.com/stretchr/testify/require"
+)
+
+func loadUser(id int) error {
+	return e2h.Tracef(errors.New("user not found"), "loading user %d", id)
+}
+
+func TestHandler_JSON(t *testing.T) {
+
+	// Setup
+	var output bytes.Buffer
+	handler, err := e2hslog.NewHandler(slog.NewJSONHandler(&output, nil), e2hslog.Options{Format: e2hformat.Format_JSON})
+	require.Nil(t, err)
+	logger := slog.New(handler).With("service", "users")
+
+	// Execute
+	logger.Error("request failed", "err", loadUser(10), slog.Group("request", "user", "john", "err", errors.New("timeout")))
+
+	// Check
+	var entry struct {
+		Service string `json:"service"`
+		Err     struct {
+			Err   string `json:"error"`
+			Stack []struct {
+				FuncName string `json:"func"`
+				Context  string `json:"context"`
+			} `json:"stack_trace"`
+		} `json:"err"`
+		Request struct {
+			User string `json:"user"`
+			Err  struct {
+				Err string `json:"error"`
+			} `json:"err"`
+		} `json:"request"`
+	}
+	require.Nil(t, json.Unmarshal(output.Bytes(), &entry))
+	require.Equal(t, "users", entry.Service)
+	require.Equal(t, "user not found", entry.Err.Err)
+	require.Len(t, entry.Err.Stack, 1)
+	require.Equal(t, "github.com/cdleo/go-e2h/slog_test.loadUser", entry.Err.Stack[0].FuncName)
+	require.Equal(t, "loading user 10", entry.Err.Stack[0].Context)
+	require.Equal(t, "john", entry.Request.User)
+	require.Equal(t, "timeout", entry.Request.Err.Err)
+}
+
+func TestHandler_Raw(t *testing.T) {
+
+	// Setup
+	var output bytes.Buffer
+	params := e2hformat.Params{Redaction: &e2hformat.RedactionPolicy{Rules: []e2hformat.RedactionRule{{Pattern: regexp.MustCompile(`user \d+`)}}}}
+	handler, err := e2hslog.NewHandler(slog.NewTextHandler(&output, nil), e2hslog.Options{Format: e2hformat.Format_Raw, Params: params})
+	require.Nil(t, err)
+	logger := slog.New(handler).WithGroup("request").With("err", loadUser(10))
+
+	// Execute
+	logger.Info("request failed")
+
+	// Check
+	require.Contains(t, output.String(), `request.err="user not found; github.com/cdleo/go-e2h/slog_test.loadUser (`)
+	require.Contains(t, output.String(), "[loading [REDACTED]];")
+	require.False(t, strings.Contains(output.String(), "loading user 10"))
+}
+
+func TestNewHandler_InvalidFormat(t *testing.T) {
+
+	// Execute
+	handler, err := e2hslog.NewHandler(slog.Default().Handler(), e2hslog.Options{Format: e2hformat.Format(10)})
+
+	// Check
+	require.Nil(t, handler)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,90 @@
+/*
+Package e2htest is the testing helpers package of the Enhanced Error Handling module
+*/
+package e2htest
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+)
+
+// This function asserts that the error was traced through the function, whose name could be
+// fully qualified ("github.com/cdleo/go-e2h_test.foo") or short ("go-e2h_test.foo", "pkg.(*Type).Method")
+func AssertTracedThrough(t testing.TB, err error, funcName string) bool {
+	t.Helper()
+
+	enhancedErr, ok := err.(e2h.EnhancedError)
+	if !ok {
+		t.Errorf("expected an enhanced error traced through [%s], got: %v", funcName, err)
+		return false
+	}
+
+	for _, item := range enhancedErr.Stack() {
+		if matchesFunc(item.FuncName, funcName) {
+			return true
+		}
+	}
+
+	t.Errorf("expected error traced through [%s], got stack:\n%s", funcName, stackFuncNames(enhancedErr))
+	return false
+}
+
+// This function asserts that some context message of the error is equal to the expected one
+func AssertContext(t testing.TB, err error, message string) bool {
+	t.Helper()
+
+	enhancedErr, ok := err.(e2h.EnhancedError)
+	if !ok {
+		t.Errorf("expected an enhanced error with context [%s], got: %v", message, err)
+		return false
+	}
+
+	messages := make([]string, 0)
+	for _, item := range enhancedErr.Stack() {
+		if item.Message == message {
+			return true
+		}
+		if len(item.Message) > 0 {
+			messages = append(messages, fmt.Sprintf("\t%q", item.Message))
+		}
+	}
+
+	t.Errorf("expected error with context [%s], got context messages:\n%s", message, strings.Join(messages, "\n"))
+	return false
+}
+
+// This function asserts that the cause of the error (or the error itself, if it's not enhanced) matches the target (see errors.Is)
+func AssertCause(t testing.TB, err error, target error) bool {
+	t.Helper()
+
+	cause := err
+	if enhancedErr, ok := err.(e2h.EnhancedError); ok {
+		cause = enhancedErr.Cause()
+	}
+
+	if !errors.Is(cause, target) {
+		t.Errorf("expected error with cause [%v], got: %v", target, cause)
+		return false
+	}
+
+	return true
+}
+
+// This function returns true if the function name is equal or ends with the expected one (at a path or package boundary)
+func matchesFunc(funcName string, expected string) bool {
+	return funcName == expected ||
+		strings.HasSuffix(funcName, "/"+expected) ||
+		strings.HasSuffix(funcName, "."+expected)
+}
+
+func stackFuncNames(err e2h.EnhancedError) string {
+	names := make([]string, 0)
+	for _, item := range err.Stack() {
+		names = append(names, "\t"+item.FuncName)
+	}
+	return strings.Join(names, "\n")
+}
@@ -0,0 +1,112 @@
+//go:build !e2h_nocaller
+// +build !e2h_nocaller
+
+/*
+Package e2htest_test its the test package of the testing helpers of the Enhanced Error Handling module
+*/
+package e2htest_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2htest "github.com/cdleo/go-e2h/test"
+	"github.com/stretchr/testify/require"
+)
+
+var errNotFound = errors.New("user not found")
+
+type service struct{}
+
+func (s *service) load() error {
+	return e2h.Tracem(fmt.Errorf("loading user: %w", errNotFound), "Error wrapped with additional info")
+}
+
+func handler() error {
+	return e2h.Tracef((&service{}).load(), "handling request %d", 10)
+}
+
+// Fake testing.TB, that records the failures instead of reporting them
+type recorderTB struct {
+	testing.TB
+	failures []string
+}
+
+func (r *recorderTB) Helper() {}
+
+func (r *recorderTB) Errorf(format string, args ...interface{}) {
+	r.failures = append(r.failures, fmt.Sprintf(format, args...))
+}
+
+func TestAssertions_Pass(t *testing.T) {
+
+	// Setup
+	err := handler()
+
+	// Execute & Check
+	require.True(t, e2htest.AssertTracedThrough(t, err, "github.com/cdleo/go-e2h/test_test.handler"))
+	require.True(t, e2htest.AssertTracedThrough(t, err, "test_test.handler"))
+	require.True(t, e2htest.AssertTracedThrough(t, err, "test_test.(*service).load"))
+	require.True(t, e2htest.AssertTracedThrough(t, err, "(*service).load"))
+	require.True(t, e2htest.AssertContext(t, err, "Error wrapped with additional info"))
+	require.True(t, e2htest.AssertContext(t, err, "handling request 10"))
+	require.True(t, e2htest.AssertCause(t, err, errNotFound))
+	require.True(t, e2htest.AssertCause(t, errNotFound, errNotFound))
+}
+
+func TestAssertions_Fail(t *testing.T) {
+
+	// Setup
+	err := handler()
+	recorder := &recorderTB{TB: t}
+
+	// Execute & Check
+	require.False(t, e2htest.AssertTracedThrough(recorder, err, "test_test.other"))
+	require.False(t, e2htest.AssertTracedThrough(recorder, err, "andler"))
+	require.False(t, e2htest.AssertTracedThrough(recorder, errNotFound, "test_test.handler"))
+	require.False(t, e2htest.AssertContext(recorder, err, "handling request 11"))
+	require.False(t, e2htest.AssertCause(recorder, err, errors.New("user not found")))
+	require.Len(t, recorder.failures, 5)
+	require.Equal(t, "expected error traced through [test_test.other], got stack:\n\tgithub.com/cdleo/go-e2h/test_test.(*service).load\n\tgithub.com/cdleo/go-e2h/test_test.handler", recorder.failures[0])
+	require.Equal(t, "expected error with context [handling request 11], got context messages:\n\t\"Error wrapped with additional info\"\n\t\"handling request 10\"", recorder.failures[3])
+}
+
+func TestNormalize(t *testing.T) {
+
+	// Setup
+	output := "cause; pkg.foo (/home/user/src/app/foo.go:24) https://github.com/org/app/blob/abc/foo.go#L24 [msg]; " +
+		"pkg.bar (C:\\src\\app\\bar.go:7); pkg.baz (github.com/org/app/baz.go:99); runtime.main ($GOROOT/src/runtime/proc.go:250);\n" +
+		"{\"caller\":\"/tmp/x/y.go:1\"}"
+
+	// Execute
+	normalized := e2htest.Normalize(output)
+
+	// Check
+	require.Equal(t, "cause; pkg.foo (<PATH>/foo.go:<LINE>) https://github.com/org/app/blob/abc/foo.go#L<LINE> [msg]; "+
+		"pkg.bar (<PATH>/bar.go:<LINE>); pkg.baz (github.com/org/app/baz.go:<LINE>); runtime.main ($GOROOT/src/runtime/proc.go:<LINE>);\n"+
+		"{\"caller\":\"<PATH>/y.go:<LINE>\"}", normalized)
+}
+
+func TestFormat_Golden(t *testing.T) {
+
+	// Execute
+	outputRaw, errRaw := e2htest.Format(handler(), e2hformat.Format_Raw, e2hformat.Params{Beautify: true})
+	outputJSON, errJSON := e2htest.Format(handler(), e2hformat.Format_JSON, e2hformat.Params{PathHidingMethod: e2hformat.HidingMethod_Module})
+	_, errUnknown := e2htest.Format(handler(), e2hformat.Format(99), e2hformat.Params{})
+
+	// Check
+	require.Nil(t, errRaw)
+	require.Equal(t, "loading user: user not found\n"+
+		"github.com/cdleo/go-e2h/test_test.(*service).load (<PATH>/assertions_test.go:<LINE>)\n"+
+		"\tError wrapped with additional info\n"+
+		"github.com/cdleo/go-e2h/test_test.handler (<PATH>/assertions_test.go:<LINE>)\n"+
+		"\thandling request 10", outputRaw)
+	require.Nil(t, errJSON)
+	require.Equal(t, "{\"error\":\"loading user: user not found\",\"stack_trace\":["+
+		"{\"func\":\"github.com/cdleo/go-e2h/test_test.(*service).load\",\"caller\":\"github.com/cdleo/go-e2h/test/assertions_test.go:<LINE>\",\"context\":\"Error wrapped with additional info\"},"+
+		"{\"func\":\"github.com/cdleo/go-e2h/test_test.handler\",\"caller\":\"github.com/cdleo/go-e2h/test/assertions_test.go:<LINE>\",\"context\":\"handling request 10\"}]}", outputJSON)
+	require.NotNil(t, errUnknown)
+}
@@ -0,0 +1,42 @@
+/*
+Package e2htest is the testing helpers package of the Enhanced Error Handling module
+*/
+package e2htest
+
+import (
+	"regexp"
+
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+)
+
+// Placeholders used by the normalized outputs
+const (
+	Placeholder_Path = "<PATH>"
+	Placeholder_Line = "<LINE>"
+)
+
+var (
+	absolutePathRegexp  = regexp.MustCompile(`(^|[\s"'(\[])(?:[A-Za-z]:)?(?:[\\/][^\s:"'()\[\]\\/]+)*[\\/]([^\s:"'()\[\]\\/]+\.(?:go|s))\b`)
+	lineNumberRegexp    = regexp.MustCompile(`(\.(?:go|s)):\d+`)
+	permalinkLineRegexp = regexp.MustCompile(`(\.(?:go|s))#L\d+`)
+)
+
+// This function returns the output of a formatter with the absolute paths and line numbers
+// replaced by placeholders (i.e. "<PATH>/file.go:<LINE>"), for golden-file comparisons.
+// The relative paths (i.e. from HidingMethod_Module) are kept
+func Normalize(output string) string {
+	output = absolutePathRegexp.ReplaceAllString(output, "${1}"+Placeholder_Path+"/${2}")
+	output = lineNumberRegexp.ReplaceAllString(output, "${1}:"+Placeholder_Line)
+	return permalinkLineRegexp.ReplaceAllString(output, "${1}#L"+Placeholder_Line)
+}
+
+// This function formats the error and returns the normalized output (see Normalize)
+func Format(err error, format e2hformat.Format, params e2hformat.Params) (string, error) {
+
+	formatter, formatterErr := e2hformat.NewFormatter(format)
+	if formatterErr != nil {
+		return "", formatterErr
+	}
+
+	return Normalize(formatter.Format(err, params)), nil
+}
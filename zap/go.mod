@@ -0,0 +1,17 @@
+module github.com/cdleo/go-e2h/zap
+
+go 1.22.0
+
+require (
+	github.com/cdleo/go-e2h v1.1.0
+	github.com/stretchr/testify v1.8.1
+	go.uber.org/zap v1.27.0
+)
+
+require (
+	github.com/cdleo/go-commons v0.0.0-20220328183115-77de79dd0070 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.uber.org/multierr v1.10.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
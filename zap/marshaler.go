@@ -0,0 +1,120 @@
+/*
+Package e2hzap is the zap integration package of the Enhanced Error Handling module
+*/
+package e2hzap
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type errorObject struct {
+	err error
+}
+
+type stackArray []e2h.StackDetails
+
+type frameObject e2h.StackDetails
+
+type fieldsObject map[string]string
+
+// This function returns a field with the error as a nested object, under the "error" key (the same as zap.Error)
+func Error(err error) zap.Field {
+	return NamedError("error", err)
+}
+
+// Same as Error, but under the provided key. Nil errors are skipped
+func NamedError(key string, err error) zap.Field {
+
+	if err == nil {
+		return zap.Skip()
+	}
+
+	return zap.Object(key, Object(err))
+}
+
+// This function returns the error as an object with the same keys of the JSON formatter: error (the cause),
+// code and template (if it's an instance of a definition, see e2h.Define), stack_trace and fields.
+// The messages and field values are redacted with the global policy (see e2hformat.SetRedactionPolicy)
+func Object(err error) zapcore.ObjectMarshaler {
+	return errorObject{err: err}
+}
+
+// This function returns the frames of the error (sorted from the deepest), or an empty array if it's not an EnhancedError
+func Stack(err error) zapcore.ArrayMarshaler {
+
+	enhancedErr, ok := err.(e2h.EnhancedError)
+	if !ok {
+		return stackArray(nil)
+	}
+
+	return stackArray(enhancedErr.Stack())
+}
+
+func (o errorObject) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+
+	enhancedErr, ok := o.err.(e2h.EnhancedError)
+	if !ok {
+		encoder.AddString("error", e2hformat.GetRedactionPolicy().Redact(o.err.Error()))
+		return nil
+	}
+
+	encoder.AddString("error", e2hformat.GetRedactionPolicy().Redact(enhancedErr.Cause().Error()))
+	if definer, ok := enhancedErr.Cause().(e2h.Definer); ok {
+		encoder.AddString("code", definer.Code())
+		encoder.AddString("template", definer.Template())
+	}
+	if err := encoder.AddArray("stack_trace", stackArray(enhancedErr.Stack())); err != nil {
+		return err
+	}
+	if fielder, ok := enhancedErr.(e2h.Fielder); ok && len(fielder.Fields()) > 0 {
+		return encoder.AddObject("fields", fieldsObject(fielder.Fields()))
+	}
+
+	return nil
+}
+
+func (a stackArray) MarshalLogArray(encoder zapcore.ArrayEncoder) error {
+
+	for _, item := range a {
+		if err := encoder.AppendObject(frameObject(item)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o frameObject) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+
+	if len(o.FuncName) > 0 {
+		encoder.AddString("func", o.FuncName)
+	}
+	if len(o.File) > 0 {
+		encoder.AddString("caller", fmt.Sprintf("%s:%d", o.File, o.Line))
+	}
+	if len(o.Message) > 0 {
+		encoder.AddString("context", e2hformat.GetRedactionPolicy().Redact(o.Message))
+	}
+
+	return nil
+}
+
+func (o fieldsObject) MarshalLogObject(encoder zapcore.ObjectEncoder) error {
+
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		encoder.AddString(name, e2hformat.GetRedactionPolicy().Redact(o[name]))
+	}
+
+	return nil
+}
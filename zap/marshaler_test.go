@@ -0,0 +1,105 @@
+/*
+Package e2hzap_test its the test package of the zap integration of the Enhanced Error Handling module
+*/
+package e2hzap_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2hzap "github.com/cdleo/go-e2h/zap"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+var errUserNotFound = e2h.Define("USER_NOT_FOUND", "user %s not found")
+
+type jsonFrame struct {
+	FuncName string `json:"func"`
+	Caller   string `json:"caller"`
+	Context  string `json:"context"`
+}
+
+type jsonError struct {
+	Err      string            `json:"error"`
+	Code     string            `json:"code"`
+	Template string            `json:"template"`
+	Stack    []jsonFrame       `json:"stack_trace"`
+	Fields   map[string]string `json:"fields"`
+}
+
+func findUser(ctx context.Context, name string) error {
+	return e2h.TracefCtx(ctx, errUserNotFound.New(name), "finding user %s", name)
+}
+
+func newLogger(output *bytes.Buffer) *zap.Logger {
+	encoder := zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig())
+	return zap.New(zapcore.NewCore(encoder, zapcore.AddSync(output), zap.DebugLevel))
+}
+
+func TestError(t *testing.T) {
+
+	// Setup
+	var output bytes.Buffer
+	logger := newLogger(&output)
+	ctx := e2h.WithField(context.Background(), e2h.Field_RequestID, "req-1")
+
+	// Execute
+	logger.Error("request failed", e2hzap.Error(e2h.Tracem(findUser(ctx, "john"), "logging in")), e2hzap.NamedError("skipped", nil))
+
+	// Check
+	var entry struct {
+		Err     jsonError        `json:"error"`
+		Skipped *json.RawMessage `json:"skipped"`
+	}
+	require.Nil(t, json.Unmarshal(output.Bytes(), &entry))
+	require.Nil(t, entry.Skipped)
+	require.Equal(t, "user john not found", entry.Err.Err)
+	require.Equal(t, "USER_NOT_FOUND", entry.Err.Code)
+	require.Equal(t, "user %s not found", entry.Err.Template)
+	require.Equal(t, map[string]string{e2h.Field_RequestID: "req-1"}, entry.Err.Fields)
+	require.Len(t, entry.Err.Stack, 3)
+	require.Equal(t, "github.com/cdleo/go-e2h/zap_test.findUser", entry.Err.Stack[0].FuncName)
+	require.True(t, strings.Contains(entry.Err.Stack[0].Caller, "marshaler_test.go:"))
+	require.Empty(t, entry.Err.Stack[0].Context)
+	require.Equal(t, "finding user john", entry.Err.Stack[1].Context)
+	require.Equal(t, "logging in", entry.Err.Stack[2].Context)
+}
+
+func TestError_StandardError(t *testing.T) {
+
+	// Setup
+	var output bytes.Buffer
+	logger := newLogger(&output)
+
+	// Execute
+	logger.Error("request failed", e2hzap.NamedError("err", errors.New("This is a standard error")))
+
+	// Check
+	require.Contains(t, output.String(), `"err":{"error":"This is a standard error"}`)
+}
+
+func TestStack_Redaction(t *testing.T) {
+
+	// Setup
+	e2hformat.SetRedactionPolicy(&e2hformat.RedactionPolicy{Rules: []e2hformat.RedactionRule{{Pattern: regexp.MustCompile(`john`)}}})
+	defer e2hformat.SetRedactionPolicy(nil)
+	var output bytes.Buffer
+	logger := newLogger(&output)
+
+	// Execute
+	logger.Info("request failed", zap.Array("frames", e2hzap.Stack(findUser(context.Background(), "john"))), zap.Array("empty", e2hzap.Stack(errors.New("error"))))
+
+	// Check
+	require.Contains(t, output.String(), `"context":"finding user [REDACTED]"}]`)
+	require.Contains(t, output.String(), `"empty":[]`)
+	require.NotContains(t, output.String(), "john")
+}
@@ -0,0 +1,19 @@
+module github.com/cdleo/go-e2h/zerolog
+
+go 1.22.0
+
+require (
+	github.com/cdleo/go-e2h v1.1.0
+	github.com/rs/zerolog v1.34.0
+	github.com/stretchr/testify v1.7.1
+)
+
+require (
+	github.com/cdleo/go-commons v0.0.0-20220328183115-77de79dd0070 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/mattn/go-colorable v0.1.13 // indirect
+	github.com/mattn/go-isatty v0.0.19 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.12.0 // indirect
+	gopkg.in/yaml.v3 v3.0.0-20210107192922-496545a6307b // indirect
+)
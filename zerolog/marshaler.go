@@ -0,0 +1,125 @@
+/*
+Package e2hzerolog is the zerolog integration package of the Enhanced Error Handling module
+*/
+package e2hzerolog
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	"github.com/rs/zerolog"
+)
+
+type errorObject struct {
+	err error
+}
+
+type stackArray []frameObject
+
+type frameObject struct {
+	FuncName string `json:"func,omitempty"`
+	Caller   string `json:"caller,omitempty"`
+	Context  string `json:"context,omitempty"`
+}
+
+type fieldsObject map[string]string
+
+// This function returns the error as an object with the same keys of the JSON formatter: error (the cause),
+// code and template (if it's an instance of a definition, see e2h.Define), stack_trace and fields.
+// The messages and field values are redacted with the global policy (see e2hformat.SetRedactionPolicy)
+func Object(err error) zerolog.LogObjectMarshaler {
+	return errorObject{err: err}
+}
+
+// This function is a zerolog.ErrorMarshalFunc that logs the enhanced errors as objects (see Object), and
+// the other ones as usual: zerolog.ErrorMarshalFunc = e2hzerolog.MarshalError
+func MarshalError(err error) interface{} {
+
+	if _, ok := err.(e2h.EnhancedError); !ok {
+		return err
+	}
+
+	return Object(err)
+}
+
+// This function is a zerolog.ErrorStackMarshaler that returns the frames of the enhanced errors (sorted from
+// the deepest), or nil for the other ones: zerolog.ErrorStackMarshaler = e2hzerolog.MarshalStack
+func MarshalStack(err error) interface{} {
+
+	enhancedErr, ok := err.(e2h.EnhancedError)
+	if !ok {
+		return nil
+	}
+
+	return newStackArray(enhancedErr.Stack())
+}
+
+func newStackArray(stack []e2h.StackDetails) stackArray {
+
+	frames := make(stackArray, 0, len(stack))
+	for _, item := range stack {
+		var caller string
+		if len(item.File) > 0 {
+			caller = fmt.Sprintf("%s:%d", item.File, item.Line)
+		}
+		frames = append(frames, frameObject{
+			FuncName: item.FuncName,
+			Caller:   caller,
+			Context:  e2hformat.GetRedactionPolicy().Redact(item.Message),
+		})
+	}
+
+	return frames
+}
+
+func (o errorObject) MarshalZerologObject(event *zerolog.Event) {
+
+	enhancedErr, ok := o.err.(e2h.EnhancedError)
+	if !ok {
+		event.Str("error", e2hformat.GetRedactionPolicy().Redact(o.err.Error()))
+		return
+	}
+
+	event.Str("error", e2hformat.GetRedactionPolicy().Redact(enhancedErr.Cause().Error()))
+	if definer, ok := enhancedErr.Cause().(e2h.Definer); ok {
+		event.Str("code", definer.Code())
+		event.Str("template", definer.Template())
+	}
+	event.Array("stack_trace", newStackArray(enhancedErr.Stack()))
+	if fielder, ok := enhancedErr.(e2h.Fielder); ok && len(fielder.Fields()) > 0 {
+		event.Object("fields", fieldsObject(fielder.Fields()))
+	}
+}
+
+func (a stackArray) MarshalZerologArray(array *zerolog.Array) {
+	for _, item := range a {
+		array.Object(item)
+	}
+}
+
+func (o frameObject) MarshalZerologObject(event *zerolog.Event) {
+
+	if len(o.FuncName) > 0 {
+		event.Str("func", o.FuncName)
+	}
+	if len(o.Caller) > 0 {
+		event.Str("caller", o.Caller)
+	}
+	if len(o.Context) > 0 {
+		event.Str("context", o.Context)
+	}
+}
+
+func (o fieldsObject) MarshalZerologObject(event *zerolog.Event) {
+
+	names := make([]string, 0, len(o))
+	for name := range o {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		event.Str(name, e2hformat.GetRedactionPolicy().Redact(o[name]))
+	}
+}
@@ -0,0 +1,117 @@
+/*
+Package e2hzerolog_test its the test package of the zerolog integration of the Enhanced Error Handling module
+*/
+package e2hzerolog_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/cdleo/go-e2h"
+	e2hformat "github.com/cdleo/go-e2h/formatter"
+	e2hzerolog "github.com/cdleo/go-e2h/zerolog"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/require"
+)
+
+var errUserNotFound = e2h.Define("USER_NOT_FOUND", "user %s not found")
+
+type jsonFrame struct {
+	FuncName string `json:"func"`
+	Caller   string `json:"caller"`
+	Context  string `json:"context"`
+}
+
+type jsonError struct {
+	Err      string            `json:"error"`
+	Code     string            `json:"code"`
+	Template string            `json:"template"`
+	Stack    []jsonFrame       `json:"stack_trace"`
+	Fields   map[string]string `json:"fields"`
+}
+
+func findUser(ctx context.Context, name string) error {
+	return e2h.TracefCtx(ctx, errUserNotFound.New(name), "finding user %s", name)
+}
+
+func TestObject(t *testing.T) {
+
+	// Setup
+	var output bytes.Buffer
+	logger := zerolog.New(&output)
+	ctx := e2h.WithField(context.Background(), e2h.Field_RequestID, "req-1")
+
+	// Execute
+	logger.Error().Object("error", e2hzerolog.Object(e2h.Tracem(findUser(ctx, "john"), "logging in"))).Msg("request failed")
+
+	// Check
+	var entry struct {
+		Err jsonError `json:"error"`
+	}
+	require.Nil(t, json.Unmarshal(output.Bytes(), &entry))
+	require.Equal(t, "user john not found", entry.Err.Err)
+	require.Equal(t, "USER_NOT_FOUND", entry.Err.Code)
+	require.Equal(t, "user %s not found", entry.Err.Template)
+	require.Equal(t, map[string]string{e2h.Field_RequestID: "req-1"}, entry.Err.Fields)
+	require.Len(t, entry.Err.Stack, 3)
+	require.Equal(t, "github.com/cdleo/go-e2h/zerolog_test.findUser", entry.Err.Stack[0].FuncName)
+	require.True(t, strings.Contains(entry.Err.Stack[0].Caller, "marshaler_test.go:"))
+	require.Empty(t, entry.Err.Stack[0].Context)
+	require.Equal(t, "finding user john", entry.Err.Stack[1].Context)
+	require.Equal(t, "logging in", entry.Err.Stack[2].Context)
+}
+
+func TestMarshalError(t *testing.T) {
+
+	// Setup
+	previous := zerolog.ErrorMarshalFunc
+	zerolog.ErrorMarshalFunc = e2hzerolog.MarshalError
+	defer func() { zerolog.ErrorMarshalFunc = previous }()
+	var output bytes.Buffer
+	logger := zerolog.New(&output)
+
+	// Execute
+	logger.Error().Err(findUser(context.Background(), "john")).Msg("enhanced")
+	logger.Error().Err(errors.New("This is a standard error")).Msg("standard")
+
+	// Check
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Contains(t, lines[0], `"error":{"error":"user john not found","code":"USER_NOT_FOUND","template":"user %s not found","stack_trace":[{"func":"github.com/cdleo/go-e2h/zerolog_test.findUser"`)
+	require.Contains(t, lines[1], `"error":"This is a standard error"`)
+}
+
+func TestMarshalStack(t *testing.T) {
+
+	// Setup
+	previous := zerolog.ErrorStackMarshaler
+	zerolog.ErrorStackMarshaler = e2hzerolog.MarshalStack
+	defer func() { zerolog.ErrorStackMarshaler = previous }()
+	e2hformat.SetRedactionPolicy(&e2hformat.RedactionPolicy{Rules: []e2hformat.RedactionRule{{Pattern: regexp.MustCompile(`john`)}}})
+	defer e2hformat.SetRedactionPolicy(nil)
+	var output bytes.Buffer
+	logger := zerolog.New(&output)
+
+	// Execute
+	logger.Error().Stack().Err(findUser(context.Background(), "john")).Msg("enhanced")
+	logger.Error().Stack().Err(errors.New("This is a standard error")).Msg("standard")
+
+	// Check
+	var entry struct {
+		Err   string      `json:"error"`
+		Stack []jsonFrame `json:"stack"`
+	}
+	lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+	require.Len(t, lines, 2)
+	require.Nil(t, json.Unmarshal([]byte(lines[0]), &entry))
+	require.Equal(t, "user john not found", entry.Err)
+	require.Len(t, entry.Stack, 2)
+	require.Equal(t, "github.com/cdleo/go-e2h/zerolog_test.findUser", entry.Stack[0].FuncName)
+	require.Equal(t, "finding user [REDACTED]", entry.Stack[1].Context)
+	require.NotContains(t, lines[1], `"stack"`)
+}